@@ -2,10 +2,17 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/AngeloMihaelle/CodeStash/internal/history"
 	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
 
@@ -13,74 +20,369 @@ import (
 )
 
 var addCmd = &cobra.Command{
-	Use:   "add",
+	Use:   "add [file|-]",
 	Short: "Add a new snippet",
-	Run: func(cmd *cobra.Command, args []string) {
-		reader := bufio.NewReader(os.Stdin)
+	Long: `Add a new snippet.
 
-		fmt.Print("📝 Title: ")
-		title, _ := reader.ReadString('\n')
+Without flags you are prompted for each field. Scripts can pass the fields as
+flags instead, taking the code from --code, --file, a file argument or stdin
+('-'). The language is inferred from the file extension or shebang when
+--lang is not given:
+  codestash add --title "Deploy" --tag ops --tag k8s --exec --file deploy.sh
+  kubectl get pods -o wide | codestash add --title "Pods" --lang text -
 
-		fmt.Print("🧾 Description: ")
-		description, _ := reader.ReadString('\n')
+With --editor the snippet is written in $VISUAL or $EDITOR as a document
+with YAML front matter, like 'codestash edit'.
 
-		fmt.Print("💻 Language: ")
-		language, _ := reader.ReadString('\n')
+With --last the previous shell command becomes the snippet, and with
+--from-history you pick one or more recent commands from your bash, zsh or
+fish history. Bash only writes history when the shell exits unless
+PROMPT_COMMAND runs 'history -a'.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		reader := bufio.NewReader(os.Stdin)
 
-		fmt.Print("🏷️ Tags (comma separated): ")
-		tagsRaw, _ := reader.ReadString('\n')
+		fromHistory, _ := cmd.Flags().GetBool("from-history")
+		last, _ := cmd.Flags().GetBool("last")
 
-		// Ask if the snippet is executable
-		fmt.Print("🚀 Is this snippet executable? (y/N): ")
-		executableRaw, _ := reader.ReadString('\n')
-		executable := strings.ToLower(strings.TrimSpace(executableRaw)) == "y" || strings.ToLower(strings.TrimSpace(executableRaw)) == "yes"
+		var s *snippet.Snippet
+		useEditor, _ := cmd.Flags().GetBool("editor")
 
-		fmt.Println("📋 Enter code (end with 'EOF' on a new line):")
-		var lines []string
-		for {
-			line, _ := reader.ReadString('\n')
-			if strings.TrimSpace(line) == "EOF" {
-				break
-			}
-			lines = append(lines, line)
+		switch {
+		case useEditor:
+			s = snippetFromEditor(cmd)
+		case fromHistory || last:
+			s = snippetFromHistory(cmd, reader, last)
+		case len(args) > 0 || anyFlagChanged(cmd, addFieldFlags...):
+			s = snippetFromFlags(cmd, args)
+		default:
+			s = promptSnippet(reader)
+		}
+		if s == nil {
+			return
+		}
+		if s.Language == "" {
+			s.Language = snippet.InferLanguage("", s.Code)
 		}
-
-		s := snippet.NewSnippet(
-			strings.TrimSpace(title),
-			strings.Join(lines, ""),
-			strings.TrimSpace(description),
-			strings.TrimSpace(language),
-			parseTags(tagsRaw),
-			executable,
-		)
 
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
+		warnSimilar(snippets, s.Code)
+
+		before := cloneSnippets(snippets)
+		s.EnsureUniqueID(snippets)
 		snippets = append(snippets, *s)
 
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("❌ Failed to save snippet:", err)
+			failf("save_failed", "Failed to save snippet: %v", err)
 			return
 		}
+		recordChange(fmt.Sprintf("Added '%s'", s.Title), before, snippets)
 
-		fmt.Println("✅ Snippet added successfully!")
-		if executable {
-			fmt.Println("🚀 This snippet is marked as executable and can be run with 'codestash exec'")
+		successf("Snippet added successfully!")
+		if s.Executable {
+			noticef("🚀", "This snippet is marked as executable and can be run with 'codestash exec'")
 		}
 	},
 }
 
-func parseTags(input string) []string {
-	parts := strings.Split(input, ",")
+// promptSnippet asks for every field of a new snippet.
+func promptSnippet(reader *bufio.Reader) *snippet.Snippet {
+	promptf("📝", "Title: ")
+	title, _ := reader.ReadString('\n')
+
+	promptf("🧾", "Description: ")
+	description, _ := reader.ReadString('\n')
+
+	promptf("💻", "Language: ")
+	language, _ := reader.ReadString('\n')
+
+	promptf("🏷️", "Tags (comma separated): ")
+	tagsRaw, _ := reader.ReadString('\n')
+
+	// Ask if the snippet is executable
+	promptf("🚀", "Is this snippet executable? (y/N): ")
+	executableRaw, _ := reader.ReadString('\n')
+	executable := strings.ToLower(strings.TrimSpace(executableRaw)) == "y" || strings.ToLower(strings.TrimSpace(executableRaw)) == "yes"
+
+	noticef("📋", "Enter code (press %s on an empty line to finish):", endOfInputKey())
+	code, _ := io.ReadAll(reader)
+
+	return snippet.NewSnippet(
+		strings.TrimSpace(title),
+		string(code),
+		strings.TrimSpace(description),
+		strings.TrimSpace(language),
+		parseTags(tagsRaw),
+		executable,
+	)
+}
+
+// snippetFromEditor opens an empty snippet document in the editor, with any
+// field flags filled in.
+func snippetFromEditor(cmd *cobra.Command) *snippet.Snippet {
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	language, _ := cmd.Flags().GetString("lang")
+	tagFlags, _ := cmd.Flags().GetStringArray("tag")
+	executable, _ := cmd.Flags().GetBool("exec")
+	code, _ := cmd.Flags().GetString("code")
+
 	var tags []string
-	for _, t := range parts {
-		if tag := strings.TrimSpace(t); tag != "" {
-			tags = append(tags, tag)
+	for _, t := range tagFlags {
+		tags = append(tags, parseTags(t)...)
+	}
+
+	s := snippet.NewSnippet(title, code, description, language, tags, executable)
+	changed, err := editInEditor(s)
+	if errors.Is(err, errEditAborted) || (err == nil && !changed) {
+		noticef("🚫", "No snippet added")
+		return nil
+	}
+	if err != nil {
+		failf("invalid_argument", "Failed to edit snippet: %v", err)
+		return nil
+	}
+	return s
+}
+
+// addFieldFlags are the flags that switch add to non-interactive mode.
+var addFieldFlags = []string{"title", "description", "lang", "tag", "exec", "file", "code"}
+
+// snippetFromFlags builds a snippet from flags, reading the code from
+// --code, --file, a file argument or stdin for "-".
+func snippetFromFlags(cmd *cobra.Command, args []string) *snippet.Snippet {
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	language, _ := cmd.Flags().GetString("lang")
+	tagFlags, _ := cmd.Flags().GetStringArray("tag")
+	executable, _ := cmd.Flags().GetBool("exec")
+	file, _ := cmd.Flags().GetString("file")
+
+	if len(args) > 0 {
+		if file != "" || cmd.Flags().Changed("code") {
+			failf("invalid_argument", "Give the code once: --code, --file or a file argument")
+			return nil
+		}
+		file = args[0]
+	}
+
+	var code string
+	switch {
+	case cmd.Flags().Changed("code"):
+		if file != "" {
+			failf("invalid_argument", "Give the code once: --code, --file or a file argument")
+			return nil
+		}
+		code, _ = cmd.Flags().GetString("code")
+	case file == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			failf("invalid_argument", "Failed to read stdin: %v", err)
+			return nil
 		}
+		code = string(data)
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			failf("invalid_argument", "Failed to read %s: %v", file, err)
+			return nil
+		}
+		code = string(data)
+	default:
+		failf("usage", "No code given. Use --code, --file, a file argument or '-' for stdin")
+		return nil
+	}
+
+	if strings.TrimSpace(code) == "" {
+		failf("invalid_argument", "Snippet code cannot be empty")
+		return nil
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" && file != "" && file != "-" {
+		title = filepath.Base(file)
+	}
+	if title == "" {
+		failf("usage", "A title is required. Use --title")
+		return nil
+	}
+
+	language = strings.TrimSpace(language)
+	if language == "" {
+		language = snippet.InferLanguage(file, code)
 	}
-	return tags
+
+	var tags []string
+	for _, t := range tagFlags {
+		tags = append(tags, parseTags(t)...)
+	}
+
+	return snippet.NewSnippet(title, code, strings.TrimSpace(description), language, tags, executable)
+}
+
+func anyFlagChanged(cmd *cobra.Command, names ...string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// endOfInputKey names the key that ends terminal input on this platform.
+func endOfInputKey() string {
+	if runtime.GOOS == "windows" {
+		return "Ctrl+Z then Enter"
+	}
+	return "Ctrl+D"
+}
+
+// snippetFromHistory builds an executable snippet from the previous shell
+// command, or from commands picked out of the recent history.
+func snippetFromHistory(cmd *cobra.Command, reader *bufio.Reader, last bool) *snippet.Snippet {
+	shell, _ := cmd.Flags().GetString("shell")
+	count, _ := cmd.Flags().GetInt("history-count")
+	if shell == "" {
+		shell = history.DetectShell()
+	}
+	if count <= 0 {
+		failf("invalid_argument", "--history-count must be positive")
+		return nil
+	}
+
+	entries, err := history.Load(shell)
+	if err != nil {
+		failf("load_failed", "Failed to read %s history: %v", shell, err)
+		return nil
+	}
+	if last {
+		count = 1
+	}
+	recent := history.Recent(entries, count, isCodestashCommand)
+	if len(recent) == 0 {
+		noticef("📭", "No commands found in your %s history", shell)
+		return nil
+	}
+
+	var commands []string
+	if last {
+		commands = []string{recent[0].Command}
+		noticef("📜", "Last command: %s", recent[0].Command)
+	} else {
+		fmt.Fprintf(os.Stderr, "%sRecent %s commands:\n", stderrEmoji("📜"), shell)
+		for i, e := range recent {
+			fmt.Fprintf(os.Stderr, "%3d  %s\n", i+1, strings.ReplaceAll(e.Command, "\n", "\n     "))
+		}
+		promptf("👉", "Select command(s), e.g. 1 or 1,3-4: ")
+		selection, _ := reader.ReadString('\n')
+		picked, err := parseSelection(selection, len(recent))
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return nil
+		}
+		// Keep the commands in the order they were run
+		sort.Sort(sort.Reverse(sort.IntSlice(picked)))
+		for _, i := range picked {
+			commands = append(commands, recent[i].Command)
+		}
+	}
+
+	code := strings.Join(commands, "\n") + "\n"
+	defaultTitle := truncateTitle(commands[0])
+
+	promptf("📝", "Title [%s]: ", defaultTitle)
+	title := readLine(reader)
+	if title == "" {
+		title = defaultTitle
+	}
+
+	promptf("🧾", "Description: ")
+	description := readLine(reader)
+
+	promptf("🏷️", "Tags (comma separated): ")
+	tagsRaw := readLine(reader)
+
+	return snippet.NewSnippet(title, code, description, shell, parseTags(tagsRaw), true)
+}
+
+// isCodestashCommand skips the codestash invocation itself, which shells
+// that append history immediately have already recorded.
+func isCodestashCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	name := filepath.Base(fields[0])
+	return name == "codestash" || name == filepath.Base(os.Args[0])
+}
+
+// parseSelection parses a list such as "1,3-4" into zero-based indices below
+// max, each once.
+func parseSelection(input string, max int) ([]int, error) {
+	seen := map[int]bool{}
+	var picked []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		end := start
+		if err == nil && isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(to))
+		}
+		if err != nil || start < 1 || end > max || start > end {
+			return nil, fmt.Errorf("invalid selection '%s', expected numbers between 1 and %d", part, max)
+		}
+		for n := start; n <= end; n++ {
+			if !seen[n-1] {
+				seen[n-1] = true
+				picked = append(picked, n-1)
+			}
+		}
+	}
+	if len(picked) == 0 {
+		return nil, fmt.Errorf("no commands selected")
+	}
+	return picked, nil
+}
+
+// truncateTitle turns the first line of a command into a default title.
+func truncateTitle(command string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(command), "\n")
+	if r := []rune(line); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return line
+}
+
+func readLine(reader *bufio.Reader) string {
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+func parseTags(input string) []string {
+	return snippet.NormalizeTags(strings.Split(input, ","))
+}
+
+func init() {
+	addCmd.Flags().String("title", "", "Snippet title (default: the file name with --file)")
+	addCmd.Flags().StringP("description", "d", "", "Snippet description")
+	addCmd.Flags().StringP("lang", "l", "", "Language (inferred from the file extension or shebang when omitted)")
+	addCmd.Flags().StringArrayP("tag", "t", nil, "Tag the snippet (repeatable, or comma separated)")
+	addCmd.Flags().BoolP("exec", "x", false, "Mark the snippet as executable")
+	addCmd.Flags().StringP("file", "f", "", "Read the code from a file ('-' for stdin)")
+	addCmd.Flags().StringP("code", "c", "", "The code itself")
+	addCmd.Flags().Bool("from-history", false, "Pick one or more recent commands from your shell history")
+	addCmd.Flags().Bool("last", false, "Save the previous shell command")
+	addCmd.Flags().String("shell", "", "History to read: "+strings.Join(history.Shells, ", ")+" (default from $SHELL)")
+	addCmd.Flags().Int("history-count", 20, "Number of recent commands to choose from")
+	addCmd.Flags().BoolP("editor", "e", false, "Write the snippet in $VISUAL or $EDITOR")
+	addCmd.MarkFlagsMutuallyExclusive("from-history", "last", "editor")
 }
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:     "backup",
+	Aliases: []string{"backups"},
+	Short:   "List, create and restore snapshots of your snippets",
+	Long: `CodeStash snapshots ~/.codestash/snippets.json into ~/.codestash/backups
+before every change. Using a snippet only updates its usage count and does
+not take a snapshot. Automatic snapshots are thinned out as they age: the
+newest 10 are always kept, then one per hour for a day and one per day for
+a month. Backups made with 'codestash backup create' are kept until you
+delete them.
+
+Examples:
+  codestash backup list
+  codestash backup create
+  codestash backup restore 20260314-0915`,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backups, newest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		backups, err := store.ListBackups()
+		if err != nil {
+			failf("load_failed", "Failed to list backups: %v", err)
+			return
+		}
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, backups); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if len(backups) == 0 {
+			noticef("📭", "No backups yet. They are made automatically before every change, or use 'codestash backup create'")
+			return
+		}
+
+		fmt.Printf("%sBackups (%d):\n", emoji("🗄️ "), len(backups))
+		for _, b := range backups {
+			count := "?"
+			if snippets, err := store.LoadBackup(b); err == nil {
+				count = fmt.Sprint(len(snippets))
+			}
+			kind := ""
+			if b.Manual {
+				kind = "  manual"
+			}
+			fmt.Printf("   %-22s  %-14s  %4s snippet(s)%s\n", b.Name, formatTimeAgo(b.Time), count, kind)
+		}
+	},
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Snapshot the current snippets and keep the backup until deleted",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := store.CreateBackup(true)
+		if err != nil {
+			failf("save_failed", "Failed to create backup: %v", err)
+			return
+		}
+		if b == nil {
+			noticef("📭", "No snippets to back up")
+			return
+		}
+		successf("Created backup %s", b.Name)
+		noticef("📁", "%s", b.Path)
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore [timestamp]",
+	Short: "Replace your snippets with a backup",
+	Long: `Replace your snippets with a backup. The timestamp can be shortened to any
+prefix, e.g. 20260314 for the last backup of that day, or 'latest'.
+
+A summary of what would change is shown before asking for confirmation.
+The current snippets are backed up first, and the restore can be undone
+with 'codestash undo'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		b, err := store.FindBackup(args[0])
+		if err != nil {
+			failf("not_found", "%v", err)
+			hintf("Use 'codestash backup list' to see the available backups")
+			return
+		}
+		restored, err := store.LoadBackup(*b)
+		if err != nil {
+			failf("load_failed", "Failed to read backup: %v", err)
+			return
+		}
+
+		// A broken store is the usual reason to restore, so only warn
+		current, err := store.LoadSnippets()
+		if err != nil {
+			warnf("Your current snippets could not be read (%v); they will be replaced", err)
+			current = nil
+		}
+
+		changes := snippet.Diff(current, restored)
+		if current != nil && len(changes) == 0 {
+			noticef("💤", "Backup %s matches your current snippets", b.Name)
+			return
+		}
+
+		added, updated, removed := 0, 0, 0
+		for _, c := range changes {
+			switch {
+			case c.Before == nil:
+				added++
+			case c.After == nil:
+				removed++
+			default:
+				updated++
+			}
+		}
+		fmt.Printf("%sRestoring %s (%s) will add %d, change %d and remove %d snippet(s)\n",
+			emoji("🗄️ "), b.Name, formatTimeAgo(b.Time), added, updated, removed)
+		if verbose || len(changes) <= 10 {
+			for _, c := range changes {
+				fmt.Printf("   %s\n", describeSnippetChange(c))
+			}
+		} else {
+			hintf("Use --verbose to list every change")
+		}
+		fmt.Println()
+
+		if !yes {
+			promptf("⚠️ ", "Restore this backup? [y/N]: ")
+			var response string
+			fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+				noticef("❌", "Restore cancelled")
+				return
+			}
+		}
+
+		if err := store.SaveSnippets(restored); err != nil {
+			failf("save_failed", "Failed to save snippets: %v", err)
+			return
+		}
+		if current != nil {
+			recordChange(fmt.Sprintf("Restored backup %s", b.Name), current, restored)
+		}
+
+		successf("Restored backup %s (%d snippet(s))", b.Name, len(restored))
+	},
+}
+
+func init() {
+	backupRestoreCmd.Flags().BoolP("yes", "y", false, "Restore without confirmation")
+	backupRestoreCmd.Flags().BoolP("verbose", "v", false, "List every snippet that changes")
+
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+}
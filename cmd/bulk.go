@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk [query]",
+	Short: "Change or delete every snippet matching a query",
+	Long: `Change or delete every snippet matching a query (the same syntax as
+'codestash search'). The affected snippets are listed and you are asked to
+confirm before anything is saved; all changes are written at once and
+recorded in the change journal.
+
+Examples:
+  codestash bulk "tag:docker" --add-tag containers --remove-tag old
+  codestash bulk "lang:sh" --set-language bash --executable true
+  codestash bulk "tag:deprecated" --delete`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addTags, _ := cmd.Flags().GetStringArray("add-tag")
+		removeTags, _ := cmd.Flags().GetStringArray("remove-tag")
+		language, _ := cmd.Flags().GetString("set-language")
+		executableRaw, _ := cmd.Flags().GetString("executable")
+		remove, _ := cmd.Flags().GetBool("delete")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		var executable *bool
+		if cmd.Flags().Changed("executable") {
+			b, err := snippet.ParseBool(executableRaw)
+			if err != nil {
+				failf("invalid_argument", "--executable: %v", err)
+				return
+			}
+			executable = &b
+		}
+		updating := len(addTags) > 0 || len(removeTags) > 0 || cmd.Flags().Changed("set-language") || executable != nil
+		if !updating && !remove {
+			failf("usage", "Nothing to do. Use --add-tag, --remove-tag, --set-language, --executable or --delete")
+			return
+		}
+		if updating && remove {
+			failf("usage", "--delete cannot be combined with other changes")
+			return
+		}
+
+		q, err := query.Parse(args[0])
+		if err != nil {
+			failf("invalid_query", "Invalid query: %v", err)
+			return
+		}
+
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+		before := cloneSnippets(snippets)
+
+		var matched []int
+		for i, s := range snippets {
+			if q.Match(s) {
+				matched = append(matched, i)
+			}
+		}
+		if len(matched) == 0 {
+			noticef("🔍", "No snippets found matching '%s'", args[0])
+			return
+		}
+
+		// Apply the changes to a copy so the preview shows only real changes
+		after := cloneSnippets(snippets)
+		var affected []int
+		for _, i := range matched {
+			s := &after[i]
+			for _, raw := range addTags {
+				for _, tag := range parseTags(raw) {
+					s.AddTag(tag)
+				}
+			}
+			for _, raw := range removeTags {
+				for _, tag := range parseTags(raw) {
+					s.RemoveTag(tag)
+				}
+			}
+			if cmd.Flags().Changed("set-language") {
+				s.Language = strings.TrimSpace(language)
+			}
+			if executable != nil {
+				s.Executable = *executable
+			}
+			if remove || !snippet.SameContent(snippets[i], *s) {
+				affected = append(affected, i)
+			}
+		}
+
+		if len(affected) == 0 {
+			noticef("💤", "All %d matching snippet(s) are already up to date", len(matched))
+			return
+		}
+
+		changes := describeBulk(addTags, removeTags, language, cmd.Flags().Changed("set-language"), executable)
+		if remove {
+			fmt.Printf("%s%d snippet(s) will be deleted:\n", emoji("🗑️ "), len(affected))
+		} else {
+			fmt.Printf("%s%d snippet(s) will be changed (%s):\n", emoji("📦"), len(affected), changes)
+		}
+		for _, i := range affected {
+			fmt.Printf("   • %s  %s\n", shortID(snippets[i].ID), snippets[i].Title)
+		}
+		fmt.Println()
+
+		if dryRun {
+			noticef("🔎", "Dry run, nothing was changed")
+			return
+		}
+
+		if !yes {
+			promptf("⚠️ ", "Apply to %d snippet(s)? [y/N]: ", len(affected))
+			var response string
+			fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+				noticef("❌", "Bulk change cancelled")
+				return
+			}
+		}
+
+		if remove {
+			after = removeIndices(after, affected)
+		}
+
+		summary := fmt.Sprintf("Updated %d snippet(s): %s", len(affected), changes)
+		if remove {
+			summary = fmt.Sprintf("Deleted %d snippet(s)", len(affected))
+		}
+
+		if err := store.SaveSnippets(after); err != nil {
+			failf("save_failed", "Failed to save snippets: %v", err)
+			return
+		}
+		recordChange(summary, before, after)
+
+		successf("%s", summary)
+	},
+}
+
+// describeBulk summarises the requested changes, e.g. "added tag(s) k8s".
+func describeBulk(addTags, removeTags []string, language string, setLanguage bool, executable *bool) string {
+	var parts []string
+	if len(addTags) > 0 {
+		parts = append(parts, "added tag(s) "+strings.Join(addTags, ", "))
+	}
+	if len(removeTags) > 0 {
+		parts = append(parts, "removed tag(s) "+strings.Join(removeTags, ", "))
+	}
+	if setLanguage {
+		parts = append(parts, fmt.Sprintf("language set to '%s'", language))
+	}
+	if executable != nil {
+		if *executable {
+			parts = append(parts, "marked executable")
+		} else {
+			parts = append(parts, "marked not executable")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	bulkCmd.Flags().StringArray("add-tag", nil, "Add a tag (repeatable)")
+	bulkCmd.Flags().StringArray("remove-tag", nil, "Remove a tag (repeatable)")
+	bulkCmd.Flags().String("set-language", "", "Set the language")
+	bulkCmd.Flags().String("executable", "", "Mark as executable: true or false")
+	bulkCmd.Flags().Bool("delete", false, "Delete the matching snippets")
+	bulkCmd.Flags().BoolP("yes", "y", false, "Apply without confirmation")
+	bulkCmd.Flags().Bool("dry-run", false, "Only show the snippets that would change")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var collectionCmd = &cobra.Command{
+	Use:     "collection",
+	Aliases: []string{"collections", "col"},
+	Short:   "Manage saved searches (smart collections)",
+	Long: `Collections are saved queries that always reflect the current snippets.
+
+They use the same query syntax as 'codestash search', for example:
+  codestash collection create ops "tag:k8s exec:true"
+  codestash list --collection ops`,
+}
+
+var collectionCreateCmd = &cobra.Command{
+	Use:   "create [name] [query]",
+	Short: "Save a query as a named collection",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, raw := strings.TrimSpace(args[0]), args[1]
+		if name == "" {
+			failf("invalid_argument", "Collection name cannot be empty")
+			return
+		}
+		if _, err := query.Parse(raw); err != nil {
+			failf("invalid_query", "Invalid query: %v", err)
+			return
+		}
+
+		collections, err := store.LoadCollections()
+		if err != nil {
+			failf("load_failed", "Failed to load collections: %v", err)
+			return
+		}
+		if findCollection(collections, name) != nil {
+			failf("conflict", "Collection '%s' already exists. Use 'codestash collection edit' to change it", name)
+			return
+		}
+
+		description, _ := cmd.Flags().GetString("description")
+		collections = append(collections, *snippet.NewCollection(name, raw, description))
+
+		if err := store.SaveCollections(collections); err != nil {
+			failf("save_failed", "Failed to save collection: %v", err)
+			return
+		}
+
+		successf("Collection '%s' created", name)
+	},
+}
+
+var collectionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved collections",
+	Run: func(cmd *cobra.Command, args []string) {
+		collections, err := store.LoadCollections()
+		if err != nil {
+			failf("load_failed", "Failed to load collections: %v", err)
+			return
+		}
+
+		if len(collections) == 0 && !structuredOutput() {
+			noticef("📭", "No collections found. Use 'codestash collection create' to save a query!")
+			return
+		}
+
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		docs := make([]collectionDoc, len(collections))
+		for i, c := range collections {
+			docs[i] = collectionDoc{Name: c.Name, Query: c.Query, Description: c.Description, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt}
+			if q, err := query.Parse(c.Query); err == nil {
+				docs[i].Snippets = len(q.Filter(snippets))
+			}
+		}
+
+		if structuredOutput() {
+			if err := writeOutputList(os.Stdout, docs); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		fmt.Printf("%sFound %d collection(s):\n\n", emoji("🗂️ "), len(collections))
+
+		for _, c := range docs {
+			fmt.Printf("%s%s — %d snippet(s)\n", emoji("🔹"), c.Name, c.Snippets)
+			fmt.Printf("   Query: %s\n", c.Query)
+			if c.Description != "" {
+				fmt.Printf("   Description: %s\n", c.Description)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// collectionDoc is the --output form of a collection in 'codestash
+// collection list'. Snippets counts the snippets its query matches now.
+type collectionDoc struct {
+	Name        string `json:"name" yaml:"name"`
+	Query       string `json:"query" yaml:"query"`
+	Description string `json:"description" yaml:"description"`
+	Snippets    int    `json:"snippets" yaml:"snippets"`
+	CreatedAt   string `json:"created_at" yaml:"created_at"`
+	UpdatedAt   string `json:"updated_at" yaml:"updated_at"`
+}
+
+var collectionEditCmd = &cobra.Command{
+	Use:   "edit [name]",
+	Short: "Change the query, name or description of a collection",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		collections, err := store.LoadCollections()
+		if err != nil {
+			failf("load_failed", "Failed to load collections: %v", err)
+			return
+		}
+
+		c := findCollection(collections, args[0])
+		if c == nil {
+			failf("not_found", "Collection '%s' not found", args[0])
+			return
+		}
+
+		changed := false
+		if cmd.Flags().Changed("query") {
+			raw, _ := cmd.Flags().GetString("query")
+			if _, err := query.Parse(raw); err != nil {
+				failf("invalid_query", "Invalid query: %v", err)
+				return
+			}
+			c.Query = raw
+			changed = true
+		}
+		if cmd.Flags().Changed("description") {
+			c.Description, _ = cmd.Flags().GetString("description")
+			changed = true
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			name = strings.TrimSpace(name)
+			if name == "" {
+				failf("invalid_argument", "Collection name cannot be empty")
+				return
+			}
+			if other := findCollection(collections, name); other != nil && other != c {
+				failf("conflict", "Collection '%s' already exists", name)
+				return
+			}
+			c.Name = name
+			changed = true
+		}
+
+		if !changed {
+			hintf("Nothing to change. Use --query, --name or --description")
+			return
+		}
+		c.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+		if err := store.SaveCollections(collections); err != nil {
+			failf("save_failed", "Failed to save collection: %v", err)
+			return
+		}
+
+		successf("Collection '%s' updated", c.Name)
+	},
+}
+
+var collectionDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a collection (snippets are not affected)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		collections, err := store.LoadCollections()
+		if err != nil {
+			failf("load_failed", "Failed to load collections: %v", err)
+			return
+		}
+
+		for i, c := range collections {
+			if strings.EqualFold(c.Name, args[0]) {
+				collections = append(collections[:i], collections[i+1:]...)
+				if err := store.SaveCollections(collections); err != nil {
+					failf("save_failed", "Failed to save collections: %v", err)
+					return
+				}
+				successf("Deleted collection '%s'", c.Name)
+				return
+			}
+		}
+
+		failf("not_found", "Collection '%s' not found", args[0])
+	},
+}
+
+func findCollection(collections []snippet.Collection, name string) *snippet.Collection {
+	for i, c := range collections {
+		if strings.EqualFold(c.Name, name) {
+			return &collections[i]
+		}
+	}
+	return nil
+}
+
+// filterByCollection returns the snippets matched by the named collection.
+func filterByCollection(snippets []snippet.Snippet, name string) ([]snippet.Snippet, error) {
+	collections, err := store.LoadCollections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collections: %v", err)
+	}
+
+	c := findCollection(collections, name)
+	if c == nil {
+		return nil, fmt.Errorf("collection '%s' not found", name)
+	}
+
+	q, err := query.Parse(c.Query)
+	if err != nil {
+		return nil, fmt.Errorf("collection '%s' has an invalid query: %v", c.Name, err)
+	}
+	return q.Filter(snippets), nil
+}
+
+func init() {
+	collectionCreateCmd.Flags().StringP("description", "d", "", "Describe what the collection is for")
+	collectionEditCmd.Flags().StringP("query", "q", "", "New query")
+	collectionEditCmd.Flags().StringP("name", "n", "", "New name")
+	collectionEditCmd.Flags().StringP("description", "d", "", "New description")
+
+	collectionCmd.AddCommand(collectionCreateCmd)
+	collectionCmd.AddCommand(collectionListCmd)
+	collectionCmd.AddCommand(collectionEditCmd)
+	collectionCmd.AddCommand(collectionDeleteCmd)
+}
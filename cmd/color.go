@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/highlight"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	colorMode    string
+	colorTheme   string
+	colorPalette string
+)
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "List syntax highlighting themes",
+	Run: func(cmd *cobra.Command, args []string) {
+		current := colorTheme
+		if current == "" {
+			current = highlight.DefaultTheme
+		}
+		for _, name := range highlight.Themes() {
+			marker := "  "
+			if name == current {
+				marker = "* "
+			}
+			fmt.Println(marker + name)
+		}
+	},
+}
+
+func validateColorFlags() error {
+	colorMode = strings.ToLower(colorMode)
+	switch colorMode {
+	case "auto", "always", "never":
+	default:
+		return fmt.Errorf("unknown color mode '%s', expected auto, always or never", colorMode)
+	}
+
+	colorPalette = strings.ToLower(colorPalette)
+	switch colorPalette {
+	case "auto", highlight.Palette16, highlight.Palette256, highlight.PaletteTruecolor:
+	case "16m", "24bit":
+		colorPalette = highlight.PaletteTruecolor
+	default:
+		return fmt.Errorf("unknown palette '%s', expected auto, 16, 256 or truecolor", colorPalette)
+	}
+	return nil
+}
+
+// colorEnabled reports whether code should be highlighted. In auto mode that
+// is when stdout is a terminal and NO_COLOR is not set.
+func colorEnabled() bool {
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if plainMode {
+		return false
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// highlightCode returns code highlighted for its language, or unchanged when
+// colour is disabled or highlighting fails.
+func highlightCode(code, language string) string {
+	if !colorEnabled() {
+		return code
+	}
+
+	palette := colorPalette
+	if palette == "auto" {
+		palette = highlight.DetectPalette()
+	}
+
+	highlighted, err := highlight.Code(code, language, highlight.Options{Palette: palette, Theme: colorTheme})
+	if err != nil {
+		warnf("Syntax highlighting disabled: %v", err)
+		colorMode = "never"
+		return code
+	}
+	return highlighted
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// plainMode drops emoji decoration and highlighting from stdout. It is set
+// by --plain, or automatically when stdout is not a terminal.
+var plainMode bool
+
+// plainStderr does the same for the diagnostics and prompts on stderr, which
+// can be redirected separately.
+var plainStderr bool
+
+// stdoutIsTerminal reports whether stdout is attached to a terminal.
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("TERM") != "dumb"
+}
+
+// stderrIsTerminal reports whether stderr is attached to a terminal.
+func stderrIsTerminal() bool {
+	return term.IsTerminal(int(os.Stderr.Fd())) && os.Getenv("TERM") != "dumb"
+}
+
+// detectPlainMode applies --plain to both streams, or otherwise turns plain
+// mode on for each stream that is not a terminal. With cmd nil, before the
+// flags are parsed, only the terminals are checked.
+func detectPlainMode(cmd *cobra.Command) {
+	if cmd != nil && cmd.Flags().Changed("plain") {
+		plainMode, _ = cmd.Flags().GetBool("plain")
+		plainStderr = plainMode
+		return
+	}
+	plainMode = !stdoutIsTerminal()
+	plainStderr = !stderrIsTerminal()
+}
+
+// rawOutput reports whether a command should print only the snippet code:
+// with --raw, or by default when stdout is not a terminal.
+func rawOutput(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("raw") {
+		raw, _ := cmd.Flags().GetBool("raw")
+		return raw
+	}
+	return !stdoutIsTerminal()
+}
+
+// writeRawCode prints code exactly as stored, adding a final newline only if
+// it is missing.
+func writeRawCode(code string) {
+	fmt.Print(code)
+	if !strings.HasSuffix(code, "\n") {
+		fmt.Println()
+	}
+}
+
+// emoji returns the decoration e followed by a space, or nothing in plain
+// mode.
+func emoji(e string) string {
+	if plainMode {
+		return ""
+	}
+	return e + " "
+}
+
+// failf reports a failed command on stderr and makes the process exit with a
+// non-zero status. In structured output modes an error object is written
+// instead of a message.
+func failf(code, format string, args ...any) {
+	reportError(errorBody{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+func reportError(body errorBody) {
+	commandFailed = true
+	if structuredOutput() {
+		writeStructuredError(body)
+		return
+	}
+	diagnostic("❌", "error: ", body.Message)
+}
+
+// warnf prints a non-fatal warning on stderr.
+func warnf(format string, args ...any) {
+	diagnostic("⚠️ ", "warning: ", fmt.Sprintf(format, args...))
+}
+
+// hintf prints a suggestion on stderr.
+func hintf(format string, args ...any) {
+	diagnostic("💡", "hint: ", fmt.Sprintf(format, args...))
+}
+
+// successf reports a completed action on stderr.
+func successf(format string, args ...any) {
+	diagnostic("✅", "", fmt.Sprintf(format, args...))
+}
+
+// noticef prints a status message with the given decoration on stderr.
+func noticef(icon, format string, args ...any) {
+	diagnostic(icon, "", fmt.Sprintf(format, args...))
+}
+
+// stderrEmoji is emoji for text written to stderr.
+func stderrEmoji(e string) string {
+	if plainStderr {
+		return ""
+	}
+	return e + " "
+}
+
+// promptf asks for input on stderr, without a trailing newline, so prompts
+// never end up in redirected output.
+func promptf(icon, format string, args ...any) {
+	fmt.Fprint(os.Stderr, stderrEmoji(icon)+fmt.Sprintf(format, args...))
+}
+
+// diagnostic writes message to stderr, decorated with icon, or with the
+// plain prefix in plain and structured modes.
+func diagnostic(icon, plainPrefix, message string) {
+	if plainStderr || structuredOutput() {
+		fmt.Fprintln(os.Stderr, plainPrefix+message)
+		return
+	}
+	fmt.Fprintln(os.Stderr, icon+" "+message)
+}
@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -14,32 +13,32 @@ var copyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
 		// Find snippet by ID or title
-		targetSnippet := findSnippet(snippets, args[0])
-		if targetSnippet == nil {
-			fmt.Printf("❌ Snippet '%s' not found\n", args[0])
+		targetSnippet, err := findSnippet(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
 
 		// Update usage stats
-		updateUsageStats(targetSnippet)
+		updateUsageStats(targetSnippet, snippet.UsageCopy)
 
 		// Save updated stats
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("⚠️  Failed to update usage stats:", err)
+			warnf("Failed to update usage stats: %v", err)
 		}
 
 		// Copy to clipboard
 		if err := copyToClipboard(targetSnippet.Code); err != nil {
-			fmt.Println("❌ Failed to copy to clipboard:", err)
+			failf("clipboard", "Failed to copy to clipboard: %v", err)
 			return
 		}
 
-		fmt.Printf("📋 Copied '%s' to clipboard\n", targetSnippet.Title)
+		noticef("📋", "Copied '%s' to clipboard", targetSnippet.Title)
 	},
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -15,53 +16,45 @@ var deleteCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
 		// Find snippet by ID or title
-		query := args[0]
-		targetIndex := -1
-		var targetSnippet *string
-
-		for i, s := range snippets {
-			if s.ID == query || strings.EqualFold(s.Title, query) {
-				targetIndex = i
-				targetSnippet = &s.Title
-				break
-			}
-		}
-
-		if targetIndex == -1 {
-			fmt.Printf("❌ Snippet '%s' not found\n", query)
+		targetIndex, err := snippet.Resolve(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
+		targetTitle := snippets[targetIndex].Title
 
 		// Get confirmation flag
 		force, _ := cmd.Flags().GetBool("force")
 
 		// Ask for confirmation unless --force is used
 		if !force {
-			fmt.Printf("⚠️  Are you sure you want to delete '%s'? [y/N]: ", *targetSnippet)
+			promptf("⚠️ ", "Are you sure you want to delete '%s'? [y/N]: ", targetTitle)
 			var response string
 			fmt.Scanln(&response)
 
 			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-				fmt.Println("❌ Deletion cancelled")
+				noticef("❌", "Deletion cancelled")
 				return
 			}
 		}
 
 		// Remove snippet from slice
+		before := cloneSnippets(snippets)
 		snippets = append(snippets[:targetIndex], snippets[targetIndex+1:]...)
 
 		// Save updated snippets
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("❌ Failed to save snippets:", err)
+			failf("save_failed", "Failed to save snippets: %v", err)
 			return
 		}
+		recordChange(fmt.Sprintf("Deleted '%s'", targetTitle), before, snippets)
 
-		fmt.Printf("✅ Deleted snippet '%s'\n", *targetSnippet)
+		successf("Deleted snippet '%s'", targetTitle)
 	},
 }
 
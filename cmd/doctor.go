@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/highlight"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:     "doctor",
+	Aliases: []string{"fsck"},
+	Short:   "Check the snippet store and your setup for problems",
+	Long: `Check the snippet store and your setup for problems:
+
+  • the store cannot be read, written or parsed
+  • duplicate or missing IDs, empty titles or code
+  • timestamps that cannot be parsed
+  • missing or unknown languages
+  • executable snippets whose shell is not installed
+  • no clipboard tool for 'codestash copy'
+
+With --fix, problems that can be repaired safely are: unreadable store
+permissions are reset, IDs are regenerated, empty titles are taken from the
+code, timestamps are reformatted and missing languages are inferred. A
+store that is not valid JSON is backed up and replaced with every snippet
+that could be recovered from it.
+
+The command exits with an error while any error-level problem remains.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		report := doctorReport{Store: store.StoragePath(), Problems: []snippet.Problem{}}
+		add := func(p snippet.Problem) { report.Problems = append(report.Problems, p) }
+
+		for _, p := range checkStorePermissions(fix) {
+			add(p)
+		}
+
+		snippets, err := store.LoadSnippets()
+		corrupt := false
+		if err != nil {
+			recovered, lost, rerr := store.RecoverSnippets()
+			if rerr != nil {
+				add(snippet.Problem{Check: "unreadable_store", Severity: snippet.SeverityError, Message: rerr.Error()})
+				finishDoctor(report, fix)
+				return
+			}
+			corrupt = true
+			snippets = recovered
+			p := snippet.Problem{
+				Check:    "corrupt_store",
+				Severity: snippet.SeverityError,
+				Message:  fmt.Sprintf("%v; %d snippet(s) can be recovered and at least %d cannot", err, len(recovered), lost),
+				Fixable:  true,
+			}
+			if fix {
+				b, berr := store.CreateBackup(true)
+				if berr != nil {
+					failf("save_failed", "Failed to back up the damaged store, nothing was changed: %v", berr)
+					return
+				}
+				p.Fixed = true
+				if b != nil {
+					p.Message += fmt.Sprintf(" (the damaged file is kept as backup %s)", b.Name)
+				}
+			}
+			add(p)
+		}
+		report.Snippets = len(snippets)
+		before := cloneSnippets(snippets)
+
+		for _, p := range snippet.CheckSnippets(snippets, fix) {
+			add(p)
+		}
+		for _, p := range checkLanguages(snippets, fix) {
+			add(p)
+		}
+		for _, p := range checkInterpreters(snippets) {
+			add(p)
+		}
+		if _, err := clipboardCommand(); err != nil {
+			add(snippet.Problem{Check: "clipboard", Severity: snippet.SeverityWarning, Message: err.Error()})
+		}
+
+		if fix && (corrupt || !reflect.DeepEqual(before, snippets)) {
+			if err := store.SaveSnippets(snippets); err != nil {
+				failf("save_failed", "Failed to save the repaired snippets: %v", err)
+				return
+			}
+			// The journal matches snippets by ID, so repairs to the IDs
+			// themselves cannot be undone; the backup taken on save covers them
+			if !corrupt && !repairedIDs(report.Problems) {
+				recordChange("Repaired snippets with doctor --fix", before, snippets)
+			}
+		}
+
+		finishDoctor(report, fix)
+	},
+}
+
+// doctorReport is the --output form of 'codestash doctor'.
+type doctorReport struct {
+	Store    string            `json:"store" yaml:"store"`
+	Snippets int               `json:"snippets" yaml:"snippets"`
+	Problems []snippet.Problem `json:"problems" yaml:"problems"`
+}
+
+// finishDoctor prints the report and fails while errors remain.
+func finishDoctor(report doctorReport, fix bool) {
+	remaining, fixable, fixed := 0, 0, 0
+	for _, p := range report.Problems {
+		switch {
+		case p.Fixed:
+			fixed++
+		case p.Severity == snippet.SeverityError:
+			remaining++
+		}
+		if p.Fixable && !p.Fixed {
+			fixable++
+		}
+	}
+
+	if structuredOutput() {
+		if err := writeOutput(os.Stdout, report); err != nil {
+			failf("output_failed", "Failed to write output: %v", err)
+			return
+		}
+	} else {
+		fmt.Printf("%sChecked %s (%d snippet(s))\n", emoji("🩺"), report.Store, report.Snippets)
+		for _, p := range report.Problems {
+			icon, label := emoji("⚠️ "), "warning"
+			if p.Severity == snippet.SeverityError {
+				icon, label = emoji("❌"), "error"
+			}
+			if p.Fixed {
+				icon, label = emoji("🔧"), "fixed"
+			}
+			if plainMode {
+				icon = label + ": "
+			}
+			subject := ""
+			if p.ID != "" || p.Title != "" {
+				subject = strings.TrimSpace(p.ID+"  "+p.Title) + ": "
+			}
+			fmt.Printf("   %s%s%s (%s)\n", icon, subject, p.Message, p.Check)
+		}
+	}
+
+	switch {
+	case len(report.Problems) == 0:
+		successf("No problems found")
+	case fixed > 0:
+		successf("Fixed %d of %d problem(s)", fixed, len(report.Problems))
+	}
+	if fixable > 0 && !fix {
+		hintf("Run 'codestash doctor --fix' to repair %d of them", fixable)
+	}
+	if remaining > 0 {
+		failf("integrity", "%d error(s) remain", remaining)
+	}
+}
+
+// checkStorePermissions makes sure the store directory and file can be read
+// and written, resetting their permissions with fix.
+func checkStorePermissions(fix bool) []snippet.Problem {
+	var problems []snippet.Problem
+	check := func(path string, mode os.FileMode, dir bool) {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		if err != nil {
+			problems = append(problems, snippet.Problem{Check: "permissions", Severity: snippet.SeverityError, Message: err.Error()})
+			return
+		}
+		err = probeAccess(path, dir)
+		if err == nil {
+			return
+		}
+		p := snippet.Problem{
+			Check:    "permissions",
+			Severity: snippet.SeverityError,
+			Message:  fmt.Sprintf("%s is not readable and writable (mode %s): %v", path, info.Mode().Perm(), err),
+			Fixable:  true,
+		}
+		if fix && os.Chmod(path, info.Mode().Perm()|mode) == nil && probeAccess(path, dir) == nil {
+			p.Fixed = true
+		}
+		problems = append(problems, p)
+	}
+	check(filepath.Dir(store.StoragePath()), 0700, true)
+	check(store.StoragePath(), 0600, false)
+	return problems
+}
+
+// probeAccess checks that path can be read and written without changing it.
+func probeAccess(path string, dir bool) error {
+	if dir {
+		f, err := os.CreateTemp(path, ".doctor-*")
+		if err != nil {
+			return err
+		}
+		f.Close()
+		return os.Remove(f.Name())
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// checkLanguages reports snippets without a language, inferring one with
+// fix, and languages that cannot be highlighted or run.
+func checkLanguages(snippets []snippet.Snippet, fix bool) []snippet.Problem {
+	var problems []snippet.Problem
+	for i := range snippets {
+		s := &snippets[i]
+		if strings.TrimSpace(s.Language) == "" {
+			inferred := snippet.InferLanguage("", s.Code)
+			p := snippet.Problem{Check: "missing_language", Severity: snippet.SeverityWarning, ID: s.ID, Title: s.Title, Message: "no language set"}
+			if inferred != "" {
+				p.Message += fmt.Sprintf(", looks like %s", inferred)
+				p.Fixable = true
+				if fix {
+					s.Language = inferred
+					p.Message += "; set it to " + inferred
+					p.Fixed = true
+				}
+			}
+			problems = append(problems, p)
+			continue
+		}
+		if !highlight.KnownLanguage(s.Language) && !isShellLanguage(s.Language) {
+			problems = append(problems, snippet.Problem{
+				Check: "unknown_language", Severity: snippet.SeverityWarning, ID: s.ID, Title: s.Title,
+				Message: fmt.Sprintf("language '%s' is not recognised, so it will not be highlighted", s.Language),
+			})
+		}
+	}
+	return problems
+}
+
+// checkInterpreters reports executable snippets whose shell is missing, in
+// which case 'codestash exec' falls back to /bin/sh or fails.
+func checkInterpreters(snippets []snippet.Snippet) []snippet.Problem {
+	var problems []snippet.Problem
+	for _, s := range snippets {
+		if !s.Executable {
+			continue
+		}
+		interpreter, fallback := snippetInterpreter(s.Language)
+		if interpreter == "" {
+			continue
+		}
+		if _, err := exec.LookPath(interpreter); err == nil {
+			continue
+		}
+		message := fmt.Sprintf("%s is not installed", interpreter)
+		if fallback != "" {
+			message += fmt.Sprintf(", so it runs with %s instead", fallback)
+		}
+		problems = append(problems, snippet.Problem{Check: "interpreter", Severity: snippet.SeverityWarning, ID: s.ID, Title: s.Title, Message: message})
+	}
+	return problems
+}
+
+// snippetInterpreter returns the program 'codestash exec' runs snippets in
+// language with on this platform, and what it falls back to if missing.
+func snippetInterpreter(language string) (interpreter, fallback string) {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(language) {
+		case "powershell", "ps1":
+			return "powershell", ""
+		}
+		return "cmd", ""
+	}
+	if shell := unixShell(language); shell != "" {
+		return shell, "/bin/sh"
+	}
+	return "/bin/sh", ""
+}
+
+func isShellLanguage(language string) bool {
+	switch strings.ToLower(language) {
+	case "shell", "bash", "sh", "zsh", "fish", "powershell", "ps1", "cmd", "bat", "batch":
+		return true
+	}
+	return false
+}
+
+func repairedIDs(problems []snippet.Problem) bool {
+	for _, p := range problems {
+		if p.Fixed && (p.Check == "missing_id" || p.Check == "duplicate_id") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Repair the problems that can be fixed safely")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var dupesCmd = &cobra.Command{
+	Use:   "dupes",
+	Short: "Find duplicate and near-duplicate snippets and offer to merge them",
+	Run: func(cmd *cobra.Command, args []string) {
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		// Get flags
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		exactOnly, _ := cmd.Flags().GetBool("exact")
+		noMerge, _ := cmd.Flags().GetBool("no-merge")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if threshold <= 0 || threshold > 1 {
+			failf("invalid_argument", "Threshold must be between 0 and 1")
+			return
+		}
+
+		var groups []snippet.DuplicateGroup
+		if exactOnly {
+			groups = snippet.FindExactDuplicates(snippets)
+		} else {
+			groups = snippet.FindDuplicates(snippets, threshold)
+		}
+
+		if len(groups) == 0 {
+			noticef("✨", "No duplicate snippets found")
+			return
+		}
+
+		fmt.Printf("%sFound %d group(s) of duplicate snippets:\n\n", emoji("🧬"), len(groups))
+
+		before := cloneSnippets(snippets)
+		var remove []int
+		moves := map[string]string{}
+		for n, g := range groups {
+			if g.Exact {
+				fmt.Printf("%d. Exact duplicates:\n", n+1)
+			} else {
+				fmt.Printf("%d. Near duplicates (%.0f%% similar):\n", n+1, g.Score*100)
+			}
+			for _, i := range g.Indices {
+				s := snippets[i]
+				fmt.Printf("   • %s  %s (%s) — used %d times\n", shortID(s.ID), s.Title, s.Language, s.UsageCount)
+			}
+
+			if noMerge {
+				fmt.Println()
+				continue
+			}
+
+			keep := &snippets[g.Indices[0]]
+			if !force {
+				promptf("🔀", "Merge into '%s' (%s)? [y/N]: ", keep.Title, shortID(keep.ID))
+				var response string
+				fmt.Scanln(&response)
+				if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+					fmt.Println("   Skipped")
+					fmt.Println()
+					continue
+				}
+			}
+
+			for _, i := range g.Indices[1:] {
+				keep.Merge(snippets[i])
+				remove = append(remove, i)
+				moves[snippets[i].ID] = keep.ID
+			}
+			fmt.Printf("   %sMerged %d snippet(s) into '%s'\n\n", emoji("✅"), len(g.Indices)-1, keep.Title)
+		}
+
+		if len(remove) == 0 {
+			return
+		}
+
+		snippets = removeIndices(snippets, remove)
+
+		if err := store.SaveSnippets(snippets); err != nil {
+			failf("save_failed", "Failed to save snippets: %v", err)
+			return
+		}
+		recordChange(fmt.Sprintf("Merged %d duplicate snippet(s)", len(remove)), before, snippets)
+		// The merged usage counts need their history to score frecency
+		if err := store.MoveUsage(moves); err != nil {
+			warnf("Failed to move the usage history of the merged snippets: %v", err)
+		}
+
+		successf("Removed %d duplicate snippet(s)", len(remove))
+	},
+}
+
+// removeIndices returns snippets without the entries at the given indices.
+func removeIndices(snippets []snippet.Snippet, indices []int) []snippet.Snippet {
+	sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+	for _, i := range indices {
+		snippets = append(snippets[:i], snippets[i+1:]...)
+	}
+	return snippets
+}
+
+// warnSimilar prints a warning for every existing snippet whose code closely
+// matches code.
+func warnSimilar(snippets []snippet.Snippet, code string) {
+	incoming := snippet.NewFingerprint(code)
+	for _, s := range snippets {
+		score := snippet.NewFingerprint(s.Code).Similarity(incoming)
+		if score < snippet.DefaultSimilarityThreshold {
+			continue
+		}
+		if score == 1 {
+			warnf("This code is identical to '%s' (%s)", s.Title, shortID(s.ID))
+		} else {
+			warnf("This code closely matches '%s' (%s, %.0f%% similar)", s.Title, shortID(s.ID), score*100)
+		}
+	}
+}
+
+func init() {
+	dupesCmd.Flags().Float64P("threshold", "t", snippet.DefaultSimilarityThreshold, "Similarity threshold between 0 and 1 for near duplicates")
+	dupesCmd.Flags().BoolP("exact", "e", false, "Only report exact duplicates (identical normalized code)")
+	dupesCmd.Flags().BoolP("no-merge", "n", false, "Only report duplicates, do not offer to merge")
+	dupesCmd.Flags().BoolP("force", "f", false, "Merge every group without confirmation")
+}
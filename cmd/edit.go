@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -14,58 +16,171 @@ import (
 var editCmd = &cobra.Command{
 	Use:   "edit [snippet-id-or-title]",
 	Short: "Edit an existing snippet",
-	Args:  cobra.ExactArgs(1),
+	Long: `Edit an existing snippet in $VISUAL or $EDITOR.
+
+The snippet opens as a document with a YAML front matter block (title,
+description, language, tags, executable) followed by the code. If the saved
+document is invalid the editor reopens with the error at the top; save an
+empty file to abort.
+
+Use --prompt to be asked for each field instead, or --field to change one.
+
+Scripts can update fields without any prompt:
+  codestash edit 3fa9 --set title="New title" --add-tag k8s --remove-tag old
+  codestash edit 3fa9 --executable=false --code-file deploy.sh
+
+--set accepts title, description, language, tags (comma separated),
+executable and code.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
+		before := cloneSnippets(snippets)
+
 		// Find snippet by ID or title
-		targetSnippet := findSnippet(snippets, args[0])
-		if targetSnippet == nil {
-			fmt.Printf("❌ Snippet '%s' not found\n", args[0])
+		targetSnippet, err := findSnippet(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
 
-		// Get field flag
+		// Get field flags
 		field, _ := cmd.Flags().GetString("field")
+		prompt, _ := cmd.Flags().GetBool("prompt")
 
-		if field != "" {
+		switch {
+		case anyFlagChanged(cmd, editSetFlags...):
+			changed, err := applyEditFlags(cmd, targetSnippet)
+			if err != nil {
+				failf("invalid_argument", "%v", err)
+				return
+			}
+			if !changed {
+				noticef("💤", "No changes made to '%s'", targetSnippet.Title)
+				return
+			}
+		case field != "":
 			// Edit specific field
 			if err := editField(targetSnippet, field); err != nil {
-				fmt.Printf("❌ Failed to edit field '%s': %v\n", field, err)
+				failf("invalid_argument", "Failed to edit field '%s': %v", field, err)
 				return
 			}
-		} else {
+		case prompt:
 			// Interactive edit of all fields
 			if err := editSnippetInteractive(targetSnippet); err != nil {
-				fmt.Printf("❌ Failed to edit snippet: %v\n", err)
+				failf("invalid_argument", "Failed to edit snippet: %v", err)
+				return
+			}
+		default:
+			changed, err := editInEditor(targetSnippet)
+			if errors.Is(err, errEditAborted) {
+				noticef("🚫", "Edit aborted, snippet '%s' was not changed", targetSnippet.Title)
+				return
+			}
+			if err != nil {
+				failf("invalid_argument", "Failed to edit snippet: %v", err)
+				return
+			}
+			if !changed {
+				noticef("💤", "No changes made to '%s'", targetSnippet.Title)
 				return
 			}
 		}
 
 		// Save updated snippets
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("❌ Failed to save snippet:", err)
+			failf("save_failed", "Failed to save snippet: %v", err)
 			return
 		}
+		recordChange(fmt.Sprintf("Edited '%s'", targetSnippet.Title), before, snippets)
 
-		fmt.Printf("✅ Snippet '%s' updated successfully!\n", targetSnippet.Title)
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, snippetDocs([]snippet.Snippet{*targetSnippet})[0]); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+		successf("Snippet '%s' updated successfully!", targetSnippet.Title)
 	},
 }
 
+// editSetFlags are the flags that update fields without prompting.
+var editSetFlags = []string{"set", "add-tag", "remove-tag", "executable", "code-file"}
+
+// applyEditFlags applies --set, --add-tag, --remove-tag, --executable and
+// --code-file to s. Nothing is changed if any of them is invalid. It reports
+// whether the snippet changed.
+func applyEditFlags(cmd *cobra.Command, s *snippet.Snippet) (bool, error) {
+	sets, _ := cmd.Flags().GetStringArray("set")
+	addTags, _ := cmd.Flags().GetStringArray("add-tag")
+	removeTags, _ := cmd.Flags().GetStringArray("remove-tag")
+	codeFile, _ := cmd.Flags().GetString("code-file")
+
+	updated := *s
+	updated.Tags = append([]string{}, s.Tags...)
+
+	for _, assignment := range sets {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return false, fmt.Errorf("invalid --set '%s', expected field=value", assignment)
+		}
+		if err := updated.SetField(name, value); err != nil {
+			return false, err
+		}
+	}
+
+	if cmd.Flags().Changed("executable") {
+		updated.Executable, _ = cmd.Flags().GetBool("executable")
+	}
+
+	if codeFile != "" {
+		var data []byte
+		var err error
+		if codeFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(codeFile)
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %v", codeFile, err)
+		}
+		if err := updated.SetField("code", string(data)); err != nil {
+			return false, err
+		}
+	}
+
+	for _, raw := range addTags {
+		for _, tag := range parseTags(raw) {
+			updated.AddTag(tag)
+		}
+	}
+	for _, raw := range removeTags {
+		for _, tag := range parseTags(raw) {
+			if !updated.RemoveTag(tag) {
+				warnf("Snippet '%s' has no tag '%s'", s.Title, tag)
+			}
+		}
+	}
+
+	changed := !snippet.SameContent(*s, updated)
+	*s = updated
+	return changed, nil
+}
+
 func editSnippetInteractive(snippet *snippet.Snippet) error {
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Printf("📝 Editing snippet: %s\n", snippet.Title)
-	fmt.Println("─────────────────────────────────────")
-	fmt.Println("Press Enter to keep current value, or type new value:")
-	fmt.Println()
+	noticef("📝", "Editing snippet: %s", snippet.Title)
+	fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
+	fmt.Fprintln(os.Stderr, "Press Enter to keep current value, or type new value:")
+	fmt.Fprintln(os.Stderr)
 
 	// Edit title
-	fmt.Printf("📝 Title [%s]: ", snippet.Title)
+	promptf("📝", "Title [%s]: ", snippet.Title)
 	newTitle, _ := reader.ReadString('\n')
 	newTitle = strings.TrimSpace(newTitle)
 	if newTitle != "" {
@@ -73,7 +188,7 @@ func editSnippetInteractive(snippet *snippet.Snippet) error {
 	}
 
 	// Edit description
-	fmt.Printf("🧾 Description [%s]: ", snippet.Description)
+	promptf("🧾", "Description [%s]: ", snippet.Description)
 	newDescription, _ := reader.ReadString('\n')
 	newDescription = strings.TrimSpace(newDescription)
 	if newDescription != "" {
@@ -81,7 +196,7 @@ func editSnippetInteractive(snippet *snippet.Snippet) error {
 	}
 
 	// Edit language
-	fmt.Printf("💻 Language [%s]: ", snippet.Language)
+	promptf("💻", "Language [%s]: ", snippet.Language)
 	newLanguage, _ := reader.ReadString('\n')
 	newLanguage = strings.TrimSpace(newLanguage)
 	if newLanguage != "" {
@@ -90,7 +205,7 @@ func editSnippetInteractive(snippet *snippet.Snippet) error {
 
 	// Edit tags
 	currentTags := strings.Join(snippet.Tags, ", ")
-	fmt.Printf("🏷️ Tags [%s]: ", currentTags)
+	promptf("🏷️", "Tags [%s]: ", currentTags)
 	newTagsRaw, _ := reader.ReadString('\n')
 	newTagsRaw = strings.TrimSpace(newTagsRaw)
 	if newTagsRaw != "" {
@@ -102,7 +217,7 @@ func editSnippetInteractive(snippet *snippet.Snippet) error {
 	if snippet.Executable {
 		executableStatus = "Yes"
 	}
-	fmt.Printf("🚀 Executable [%s] (y/n): ", executableStatus)
+	promptf("🚀", "Executable [%s] (y/n): ", executableStatus)
 	newExecutableRaw, _ := reader.ReadString('\n')
 	newExecutableRaw = strings.TrimSpace(newExecutableRaw)
 	if newExecutableRaw != "" {
@@ -110,28 +225,19 @@ func editSnippetInteractive(snippet *snippet.Snippet) error {
 	}
 
 	// Edit code
-	fmt.Printf("📋 Edit code? (y/N): ")
+	promptf("📋", "Edit code? (y/N): ")
 	editCodeRaw, _ := reader.ReadString('\n')
 	editCodeRaw = strings.TrimSpace(editCodeRaw)
 	if strings.ToLower(editCodeRaw) == "y" || strings.ToLower(editCodeRaw) == "yes" {
-		fmt.Println("📋 Enter new code (end with 'EOF' on a new line):")
-		fmt.Println("Current code:")
-		fmt.Println("─────────────────────────────────────")
-		fmt.Println(snippet.Code)
-		fmt.Println("─────────────────────────────────────")
-		fmt.Println()
-
-		var lines []string
-		for {
-			line, _ := reader.ReadString('\n')
-			if strings.TrimSpace(line) == "EOF" {
-				break
-			}
-			lines = append(lines, line)
-		}
+		noticef("📋", "Enter new code (press %s on an empty line to finish):", endOfInputKey())
+		fmt.Fprintln(os.Stderr, "Current code:")
+		fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
+		fmt.Fprintln(os.Stderr, snippet.Code)
+		fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
+		fmt.Fprintln(os.Stderr)
 
-		if len(lines) > 0 {
-			snippet.Code = strings.Join(lines, "")
+		if code, _ := io.ReadAll(reader); len(code) > 0 {
+			snippet.Code = string(code)
 		}
 	}
 
@@ -143,8 +249,8 @@ func editField(snippet *snippet.Snippet, field string) error {
 
 	switch strings.ToLower(field) {
 	case "title":
-		fmt.Printf("📝 Current title: %s\n", snippet.Title)
-		fmt.Print("📝 New title: ")
+		noticef("📝", "Current title: %s", snippet.Title)
+		promptf("📝", "New title: ")
 		newTitle, _ := reader.ReadString('\n')
 		newTitle = strings.TrimSpace(newTitle)
 		if newTitle == "" {
@@ -153,8 +259,8 @@ func editField(snippet *snippet.Snippet, field string) error {
 		snippet.Title = newTitle
 
 	case "description":
-		fmt.Printf("🧾 Current description: %s\n", snippet.Description)
-		fmt.Print("🧾 New description: ")
+		noticef("🧾", "Current description: %s", snippet.Description)
+		promptf("🧾", "New description: ")
 		newDescription, _ := reader.ReadString('\n')
 		newDescription = strings.TrimSpace(newDescription)
 		if newDescription != "" {
@@ -162,8 +268,8 @@ func editField(snippet *snippet.Snippet, field string) error {
 		}
 
 	case "language":
-		fmt.Printf("💻 Current language: %s\n", snippet.Language)
-		fmt.Print("💻 New language: ")
+		noticef("💻", "Current language: %s", snippet.Language)
+		promptf("💻", "New language: ")
 		newLanguage, _ := reader.ReadString('\n')
 		newLanguage = strings.TrimSpace(newLanguage)
 		if newLanguage != "" {
@@ -172,8 +278,8 @@ func editField(snippet *snippet.Snippet, field string) error {
 
 	case "tags":
 		currentTags := strings.Join(snippet.Tags, ", ")
-		fmt.Printf("🏷️ Current tags: %s\n", currentTags)
-		fmt.Print("🏷️ New tags (comma separated): ")
+		noticef("🏷️", "Current tags: %s", currentTags)
+		promptf("🏷️", "New tags (comma separated): ")
 		newTagsRaw, _ := reader.ReadString('\n')
 		newTagsRaw = strings.TrimSpace(newTagsRaw)
 		if newTagsRaw != "" {
@@ -185,8 +291,8 @@ func editField(snippet *snippet.Snippet, field string) error {
 		if snippet.Executable {
 			executableStatus = "Yes"
 		}
-		fmt.Printf("🚀 Current executable status: %s\n", executableStatus)
-		fmt.Print("🚀 New executable status (y/n): ")
+		noticef("🚀", "Current executable status: %s", executableStatus)
+		promptf("🚀", "New executable status (y/n): ")
 		newExecutableRaw, _ := reader.ReadString('\n')
 		newExecutableRaw = strings.TrimSpace(newExecutableRaw)
 		if newExecutableRaw != "" {
@@ -194,23 +300,14 @@ func editField(snippet *snippet.Snippet, field string) error {
 		}
 
 	case "code":
-		fmt.Println("📋 Current code:")
-		fmt.Println("─────────────────────────────────────")
-		fmt.Println(snippet.Code)
-		fmt.Println("─────────────────────────────────────")
-		fmt.Println("📋 Enter new code (end with 'EOF' on a new line):")
-
-		var lines []string
-		for {
-			line, _ := reader.ReadString('\n')
-			if strings.TrimSpace(line) == "EOF" {
-				break
-			}
-			lines = append(lines, line)
-		}
+		noticef("📋", "Current code:")
+		fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
+		fmt.Fprintln(os.Stderr, snippet.Code)
+		fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
+		noticef("📋", "Enter new code (press %s on an empty line to finish):", endOfInputKey())
 
-		if len(lines) > 0 {
-			snippet.Code = strings.Join(lines, "")
+		if code, _ := io.ReadAll(reader); len(code) > 0 {
+			snippet.Code = string(code)
 		}
 
 	default:
@@ -222,4 +319,10 @@ func editField(snippet *snippet.Snippet, field string) error {
 
 func init() {
 	editCmd.Flags().StringP("field", "f", "", "Edit specific field (title, description, language, tags, executable, code)")
+	editCmd.Flags().BoolP("prompt", "p", false, "Prompt for each field instead of opening an editor")
+	editCmd.Flags().StringArray("set", nil, "Set a field, e.g. --set title=\"New title\" (repeatable)")
+	editCmd.Flags().StringArray("add-tag", nil, "Add a tag (repeatable)")
+	editCmd.Flags().StringArray("remove-tag", nil, "Remove a tag (repeatable)")
+	editCmd.Flags().Bool("executable", false, "Mark the snippet as executable, or not with --executable=false")
+	editCmd.Flags().String("code-file", "", "Replace the code with the contents of a file ('-' for stdin)")
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// errEditAborted is returned when the user leaves the editor without a
+// usable document.
+var errEditAborted = errors.New("edit aborted")
+
+// editorCommand returns the user's editor from $VISUAL or $EDITOR, which may
+// include arguments such as "code --wait".
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// editInEditor opens s in the user's editor as a front matter document and
+// applies the result. A document that does not parse is reopened with the
+// error noted at the top. It reports whether anything changed; saving an
+// empty file, or reopening a broken document and saving it unchanged,
+// aborts the edit.
+func editInEditor(s *snippet.Snippet) (bool, error) {
+	file, err := os.CreateTemp("", "codestash-*.md")
+	if err != nil {
+		return false, err
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	original := snippet.FormatDocument(*s)
+	doc := original
+	var previous []byte
+	for {
+		if err := os.WriteFile(path, doc, 0600); err != nil {
+			return false, err
+		}
+
+		editor := editorCommand()
+		cmd := exec.Command(editor[0], append(editor[1:], path)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return false, fmt.Errorf("editor '%s' failed: %v", strings.Join(editor, " "), err)
+		}
+
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		if len(bytes.TrimSpace(edited)) == 0 {
+			return false, errEditAborted
+		}
+		if bytes.Equal(edited, original) {
+			return false, nil
+		}
+		if bytes.Equal(edited, doc) || bytes.Equal(edited, previous) {
+			// Reopened with an error and saved without fixing it
+			return false, errEditAborted
+		}
+		previous = edited
+
+		updated := *s
+		if err := snippet.ParseDocument(edited, &updated); err != nil {
+			warnf("Invalid snippet: %v", err)
+			noticef("📝", "Reopening the editor...")
+			doc = snippet.AnnotateDocument(edited, fmt.Sprintf("error: %v\nFix it and save, or save unchanged to give up.", err))
+			continue
+		}
+		*s = updated
+		return true, nil
+	}
+}
@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -16,40 +15,40 @@ var execCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
 		// Find snippet by ID or title
-		targetSnippet := findSnippet(snippets, args[0])
-		if targetSnippet == nil {
-			fmt.Printf("❌ Snippet '%s' not found\n", args[0])
+		targetSnippet, err := findSnippet(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
 
 		// Check if snippet is marked as executable (unless --force is used)
 		if !targetSnippet.Executable && !forceExec {
-			fmt.Printf("❌ Snippet '%s' is not marked as executable\n", targetSnippet.Title)
-			fmt.Println("💡 Use 'codestash edit' to mark it as executable, or use 'codestash exec --force' to force execution")
+			failf("not_executable", "Snippet '%s' is not marked as executable", targetSnippet.Title)
+			hintf("Use 'codestash edit' to mark it as executable, or use 'codestash exec --force' to force execution")
 			return
 		}
 
 		// Show warning if forcing execution of non-executable snippet
 		if !targetSnippet.Executable && forceExec {
-			fmt.Printf("⚠️  Forcing execution of non-executable snippet '%s'\n", targetSnippet.Title)
+			warnf("Forcing execution of non-executable snippet '%s'", targetSnippet.Title)
 		}
 
 		// Update usage stats
-		updateUsageStats(targetSnippet)
+		updateUsageStats(targetSnippet, snippet.UsageExec)
 
 		// Save updated stats
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("⚠️  Failed to update usage stats:", err)
+			warnf("Failed to update usage stats: %v", err)
 		}
 
 		// Execute the snippet
 		if err := executeSnippet(targetSnippet); err != nil {
-			fmt.Println("❌ Failed to execute snippet:", err)
+			failf("exec_failed", "Failed to execute snippet: %v", err)
 			return
 		}
 	},
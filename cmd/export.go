@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/bundle"
+	"github.com/AngeloMihaelle/CodeStash/internal/interop"
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// Formats accepted by export --format.
+const (
+	exportBundle = "bundle"
+	exportVSCode = "vscode"
+)
+
+var exportFormats = []string{exportBundle, exportVSCode}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [query]",
+	Short: "Export snippets to a portable bundle or VS Code snippets",
+	Long: `Export snippets to a bundle file that 'codestash import' can read on
+another machine. An optional query (the same syntax as 'codestash search')
+selects which snippets are exported.
+
+--format vscode writes a VS Code *.code-snippets file instead, turning
+{{name}} placeholders into tab stops.
+
+Examples:
+  codestash export -o all.cstash
+  codestash export "lang:go" -o go.code-snippets
+  codestash export "tag:k8s exec:true" -o team.cstash`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		outPath, _ := cmd.Flags().GetString("out")
+		collectionName, _ := cmd.Flags().GetString("collection")
+		keepUsage, _ := cmd.Flags().GetBool("with-usage")
+
+		if collectionName != "" {
+			snippets, err = filterByCollection(snippets, collectionName)
+			if err != nil {
+				failf("collection", "%v", err)
+				return
+			}
+		}
+		if len(args) == 1 {
+			q, err := query.Parse(args[0])
+			if err != nil {
+				failf("invalid_query", "Invalid query: %v", err)
+				return
+			}
+			snippets = q.Filter(snippets)
+		}
+
+		if len(snippets) == 0 {
+			warnf("No snippets matched, the bundle will be empty")
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		if !cmd.Flags().Changed("format") && strings.HasSuffix(strings.ToLower(outPath), ".code-snippets") {
+			format = exportVSCode
+		}
+
+		var buf bytes.Buffer
+		switch strings.ToLower(format) {
+		case exportBundle:
+			err = bundle.Write(&buf, bundle.New(snippets, keepUsage))
+		case exportVSCode:
+			err = interop.WriteVSCode(&buf, snippets)
+		default:
+			failf("invalid_argument", "Unknown export format '%s'. Valid formats: %s", format, strings.Join(exportFormats, ", "))
+			return
+		}
+		if err != nil {
+			failf("output_failed", "Failed to encode snippets: %v", err)
+			return
+		}
+
+		if outPath == "" || outPath == "-" {
+			if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+				failf("output_failed", "Failed to write bundle: %v", err)
+			}
+			return
+		}
+
+		if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+			failf("save_failed", "Failed to write bundle: %v", err)
+			return
+		}
+		successf("Exported %d snippet(s) to %s", len(snippets), outPath)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringP("format", "f", exportBundle, "Output format: "+strings.Join(exportFormats, " or ")+"; an --out file ending in .code-snippets defaults to vscode")
+	exportCmd.Flags().StringP("out", "o", "", "Write the bundle to a file instead of stdout")
+	exportCmd.Flags().StringP("collection", "c", "", "Export only snippets in a saved collection")
+	exportCmd.Flags().Bool("with-usage", false, "Include usage counts and last-used times")
+}
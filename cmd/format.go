@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+// templateSnippet is the value passed to --format templates: every field of
+// snippet.Snippet plus a few computed ones.
+type templateSnippet struct {
+	snippet.Snippet
+	ShortID     string
+	Frecency    float64
+	Preview     string
+	Age         string
+	LastUsedAgo string
+}
+
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"truncate": func(n int, s string) string {
+		runes := []rune(s)
+		if n < 0 || len(runes) <= n {
+			return s
+		}
+		if n <= 3 {
+			return string(runes[:n])
+		}
+		return string(runes[:n-3]) + "..."
+	},
+	"firstLine": func(s string) string {
+		line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+		return line
+	},
+	"ago": func(timestamp string) string {
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return ""
+		}
+		return formatTimeAgo(t)
+	},
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// addFormatFlag registers --format on cmd.
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().String("format", "", "Format each snippet with a Go template, e.g. '{{.ID}}\\t{{.Title}}'")
+}
+
+// parseFormatFlag returns the compiled --format template, or nil if the flag
+// is not set. The escapes \t and \n are interpreted so templates can be
+// written in single quotes on the shell.
+func parseFormatFlag(cmd *cobra.Command) (*template.Template, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		return nil, nil
+	}
+	if structuredOutput() {
+		return nil, fmt.Errorf("--format cannot be combined with --output %s", outputFormat)
+	}
+
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+	tmpl, err := template.New("format").Funcs(templateFuncs).Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// writeFormatted executes tmpl once per snippet, each followed by a newline.
+// previewQuery selects the code line used for .Preview.
+func writeFormatted(w io.Writer, tmpl *template.Template, snippets []snippet.Snippet, previewQuery string) error {
+	scores := loadFrecency(snippets)
+	for _, s := range snippets {
+		data := templateSnippet{
+			Snippet:  s,
+			ShortID:  shortID(s.ID),
+			Frecency: scores[s.ID],
+			Preview:  getCodePreview(s.Code, previewQuery),
+		}
+		if created, err := time.Parse(time.RFC3339, s.CreatedAt); err == nil {
+			data.Age = formatTimeAgo(created)
+		}
+		if lastUsed, err := time.Parse(time.RFC3339, s.LastUsed); err == nil {
+			data.LastUsedAgo = formatTimeAgo(lastUsed)
+		}
+
+		if err := tmpl.Execute(w, data); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
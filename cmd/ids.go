@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+)
+
+// shortIDs caches the display aliases of the IDs in the store, loaded on
+// first use.
+var shortIDs map[string]string
+
+// shortID returns the alias shown for a snippet ID in text output: the
+// shortest prefix that is unique in the store, so it can be passed back to
+// any command. IDs that are not in the store, such as deleted snippets, are
+// shown in full.
+func shortID(id string) string {
+	if shortIDs == nil {
+		shortIDs = map[string]string{}
+		if snippets, err := store.LoadSnippets(); err == nil {
+			ids := make([]string, len(snippets))
+			for i, s := range snippets {
+				ids[i] = s.ID
+			}
+			shortIDs = snippet.ShortIDs(ids)
+		}
+	}
+	if short, ok := shortIDs[id]; ok {
+		return short
+	}
+	return id
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/bundle"
+	"github.com/AngeloMihaelle/CodeStash/internal/interop"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file|directory]",
+	Short: "Import snippets from a bundle or another tool",
+	Long: `Import snippets from a bundle written by 'codestash export' (or a copy of
+snippets.json). Use '-' to read from stdin.
+
+--from vscode reads VS Code snippet files (*.code-snippets or per-language
+files such as go.json): the prefix becomes the title, the scope the language,
+and tab stops like $1 or ${1:default} become {{1}} and {{1:default}}
+placeholders.
+
+--from pet, navi and tldr read pet's snippet.toml, navi .cheat files and
+tldr pages. Their <name>, <name=default> and {{value}} variables become
+placeholders. Commands are imported as executable bash snippets, except
+those with placeholders, which need filling in before they can run. Pass a
+directory to import every matching file in it, e.g. a tldr pages folder.
+
+The format is detected from the file name by default.
+
+An imported snippet conflicts with an existing one that has the same code or
+title (or the same ID as well as one of those). --on-conflict decides what
+happens:
+  skip       keep the existing snippet (default)
+  overwrite  replace its title, code, tags and other fields
+  rename     add the imported snippet with a new ID and title
+  merge      keep the existing code, combine tags and fill in missing fields
+
+Identical snippets are always skipped, and imported IDs that are already in
+use are replaced with new ones.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		policyFlag, _ := cmd.Flags().GetString("on-conflict")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		policy, err := bundle.ParsePolicy(policyFlag)
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+
+		from, _ := cmd.Flags().GetString("from")
+		incoming, format, err := readImport(args[0], strings.ToLower(from))
+		if err != nil {
+			failf("invalid_argument", "Failed to read %s: %v", args[0], err)
+			return
+		}
+
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		merged, report := bundle.Merge(snippets, incoming, policy)
+
+		if !dryRun && report.Added+report.Updated > 0 {
+			if err := store.SaveSnippets(merged); err != nil {
+				failf("save_failed", "Failed to save snippets: %v", err)
+				return
+			}
+			recordChange(fmt.Sprintf("Imported %s: %d added, %d updated", args[0], report.Added, report.Updated), snippets, merged)
+		}
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, report); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+				return
+			}
+			warnPlaceholderCommands(format, report, merged)
+			return
+		}
+
+		displayImportReport(report)
+		warnPlaceholderCommands(format, report, merged)
+		summary := fmt.Sprintf("%d added, %d updated, %d skipped", report.Added, report.Updated, report.Skipped)
+		if dryRun {
+			noticef("🔎", "Dry run, nothing was saved: %s", summary)
+			return
+		}
+		successf("Imported %s: %s", args[0], summary)
+	},
+}
+
+// Formats accepted by import --from.
+const (
+	importAuto   = "auto"
+	importBundle = "bundle"
+	importVSCode = "vscode"
+	importPet    = "pet"
+	importNavi   = "navi"
+	importTldr   = "tldr"
+)
+
+var importFormats = []string{importAuto, importBundle, importVSCode, importPet, importNavi, importTldr}
+
+// importExtensions are the files read from a directory for each format.
+var importExtensions = map[string][]string{
+	importBundle: {bundle.Extension},
+	importVSCode: {".code-snippets"},
+	importPet:    {".toml"},
+	importNavi:   {".cheat"},
+	importTldr:   {".md"},
+}
+
+// readImport reads the snippets in path, or stdin for "-", in the given
+// format, and returns the format it read. The auto format is detected from
+// the file name and contents. A directory is searched recursively for files
+// of the format.
+func readImport(path, from string) ([]snippet.Snippet, string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, from, err
+		}
+		if from == importAuto {
+			from = detectImportFormat(path, data)
+		}
+		snippets, err := parseImport(data, path, from)
+		return snippets, from, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, from, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, from, err
+		}
+		if from == importAuto {
+			from = detectImportFormat(path, data)
+		}
+		snippets, err := parseImport(data, path, from)
+		return snippets, from, err
+	}
+
+	extensions, ok := importExtensions[from]
+	if !ok {
+		return nil, from, fmt.Errorf("%s is a directory, use --from to say which files to read (%s)", path, strings.Join(importFormats[1:], ", "))
+	}
+
+	var snippets []snippet.Snippet
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !hasExtension(file, extensions) {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		found, err := parseImport(data, file, from)
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		snippets = append(snippets, found...)
+		return nil
+	})
+	return snippets, from, err
+}
+
+func parseImport(data []byte, name, from string) ([]snippet.Snippet, error) {
+	r := bytes.NewReader(data)
+	switch from {
+	case importBundle:
+		return bundle.Read(r)
+	case importVSCode:
+		return interop.ReadVSCode(r, name)
+	case importPet:
+		return interop.ReadPet(r)
+	case importNavi:
+		return interop.ReadNavi(r)
+	case importTldr:
+		return interop.ReadTldr(r, name)
+	}
+	return nil, fmt.Errorf("unknown import format '%s'. Valid formats: %s", from, strings.Join(importFormats, ", "))
+}
+
+// detectImportFormat guesses the format from the file extension, telling
+// VS Code snippet files apart from bundles by the bundle's "format" marker.
+func detectImportFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".code-snippets":
+		return importVSCode
+	case ".toml":
+		return importPet
+	case ".cheat":
+		return importNavi
+	case ".md":
+		return importTldr
+	}
+	if bytes.Contains(data, []byte(`"`+bundle.Format+`"`)) || strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		return importBundle
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return importVSCode
+	}
+	return importBundle
+}
+
+// warnPlaceholderCommands warns about the commands added or updated from
+// pet, navi or tldr that were left non-executable because they have
+// placeholders.
+func warnPlaceholderCommands(format string, report bundle.Report, merged []snippet.Snippet) {
+	if format != importPet && format != importNavi && format != importTldr {
+		return
+	}
+	changed := map[string]bool{}
+	for _, c := range report.Changes {
+		if c.Action == bundle.ActionAdded || c.Action == bundle.ActionUpdated {
+			changed[c.ID] = true
+		}
+	}
+	n := 0
+	for _, s := range merged {
+		if changed[s.ID] && !s.Executable && len(snippet.Placeholders(s.Code)) > 0 {
+			n++
+		}
+	}
+	if n > 0 {
+		warnf("%d imported command(s) have placeholders and were made non-executable. Fill them in, then run 'codestash edit <id> --executable true'", n)
+	}
+}
+
+func hasExtension(path string, extensions []string) bool {
+	for _, ext := range extensions {
+		if strings.EqualFold(filepath.Ext(path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func displayImportReport(report bundle.Report) {
+	for _, c := range report.Changes {
+		var line string
+		switch c.Action {
+		case bundle.ActionAdded:
+			line = fmt.Sprintf("%s%s  %s", emoji("➕"), shortID(c.ID), c.Title)
+			if c.OriginalID != "" {
+				line += fmt.Sprintf(" (new ID, was %s)", c.OriginalID)
+			}
+		case bundle.ActionUpdated:
+			line = fmt.Sprintf("%s%s  %s (%s)", emoji("🔄"), shortID(c.ID), c.Title, c.Reason)
+		default:
+			line = fmt.Sprintf("%s%s  %s (%s)", emoji("⏭️ "), c.ID, c.Title, c.Reason)
+		}
+		fmt.Println(line)
+	}
+	if len(report.Changes) > 0 {
+		fmt.Println()
+	}
+}
+
+func init() {
+	importCmd.Flags().String("on-conflict", string(bundle.Skip), "What to do with conflicting snippets: skip, overwrite, rename or merge")
+	importCmd.Flags().String("from", importAuto, "Input format: "+strings.Join(importFormats, ", "))
+	importCmd.Flags().Bool("dry-run", false, "Show what would be imported without saving")
+}
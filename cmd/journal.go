@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+)
+
+// recordChange adds the difference between before and after to the change
+// journal. A failure only warns, since the change itself has been saved.
+func recordChange(summary string, before, after []snippet.Snippet) {
+	entry := snippet.NewJournalEntry(commandLine(), summary, before, after)
+	if entry == nil {
+		return
+	}
+	if err := store.RecordChange(*entry); err != nil {
+		warnf("Failed to record the change in the journal: %v", err)
+	}
+}
+
+// cloneSnippets copies snippets deeply enough that later edits to the
+// originals, including their tags, do not show through.
+func cloneSnippets(snippets []snippet.Snippet) []snippet.Snippet {
+	clone := make([]snippet.Snippet, len(snippets))
+	for i, s := range snippets {
+		if s.Tags != nil {
+			s.Tags = append([]string{}, s.Tags...)
+		}
+		clone[i] = s
+	}
+	return clone
+}
+
+// commandLine returns the command being run, for the journal.
+func commandLine() string {
+	return "codestash " + strings.Join(os.Args[1:], " ")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
@@ -15,18 +16,34 @@ var listCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
-		if len(snippets) == 0 {
-			fmt.Println("📭 No snippets found. Use 'codestash add' to create your first snippet!")
+		tmpl, err := parseFormatFlag(cmd)
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+		quiet := structuredOutput() || tmpl != nil
+
+		if len(snippets) == 0 && !quiet {
+			noticef("📭", "No snippets found. Use 'codestash add' to create your first snippet!")
 			return
 		}
 
 		// Get filter flags
 		language, _ := cmd.Flags().GetString("language")
 		tag, _ := cmd.Flags().GetString("tag")
+		collectionName, _ := cmd.Flags().GetString("collection")
+
+		if collectionName != "" {
+			snippets, err = filterByCollection(snippets, collectionName)
+			if err != nil {
+				failf("collection", "%v", err)
+				return
+			}
+		}
 
 		// Filter snippets
 		var filteredSnippets []snippet.Snippet
@@ -34,60 +51,109 @@ var listCmd = &cobra.Command{
 			if language != "" && s.Language != language {
 				continue
 			}
-			if tag != "" && !contains(s.Tags, tag) {
+			if tag != "" && !s.MatchesTag(tag) {
 				continue
 			}
 			filteredSnippets = append(filteredSnippets, s)
 		}
 
+		if len(filteredSnippets) == 0 && !quiet {
+			noticef("📭", "No snippets match your filters.")
+			return
+		}
+
+		// Apply sorting and pagination
+		total := len(filteredSnippets)
+		offset, err := sortAndPaginate(cmd, &filteredSnippets, "")
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+
+		if structuredOutput() {
+			if err := writeOutputList(os.Stdout, snippetDocs(filteredSnippets)); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if tmpl != nil {
+			out := newPagedOutput(cmd)
+			defer out.Flush()
+			if err := writeFormatted(out, tmpl, filteredSnippets, ""); err != nil {
+				failf("invalid_argument", "Failed to apply --format: %v", err)
+			}
+			return
+		}
+
+		// Compact table view
+		table, _ := cmd.Flags().GetBool("table")
+		wide, _ := cmd.Flags().GetBool("wide")
+		columnsRaw, _ := cmd.Flags().GetString("columns")
+
+		if table || wide || columnsRaw != "" {
+			columns, err := parseTableColumns(columnsRaw, wide)
+			if err != nil {
+				failf("invalid_argument", "%v", err)
+				return
+			}
+
+			maxWidth := 0
+			if !wide {
+				maxWidth = terminalWidth()
+			}
+
+			out := newPagedOutput(cmd)
+			defer out.Flush()
+			writeTable(out, columns, filteredSnippets, maxWidth)
+			return
+		}
 		if len(filteredSnippets) == 0 {
-			fmt.Println("📭 No snippets match your filters.")
+			noticef("📭", "No snippets past offset %d (%d match).", offset, total)
 			return
 		}
 
 		// Get expanded flag
 		expanded, _ := cmd.Flags().GetBool("expanded")
 
-		fmt.Printf("📚 Found %d snippet(s):\n\n", len(filteredSnippets))
+		out := newPagedOutput(cmd)
+		defer out.Flush()
+
+		fmt.Fprintf(out, "%sFound %d snippet(s)%s:\n\n", emoji("📚"), total, pageInfo(offset, len(filteredSnippets), total))
 
 		for _, s := range filteredSnippets {
-			fmt.Printf("🔹 ID: %s\n", s.ID)
-			fmt.Printf("   Title: %s\n", s.Title)
-			fmt.Printf("   Language: %s\n", s.Language)
-			fmt.Printf("   Tags: %s\n", strings.Join(s.Tags, ", "))
-			fmt.Printf("   Description: %s\n", s.Description)
-			fmt.Printf("   Executable: %t\n", s.Executable)
-			fmt.Printf("   Used: %d times\n", s.UsageCount)
+			fmt.Fprintf(out, "%sID: %s\n", emoji("🔹"), shortID(s.ID))
+			fmt.Fprintf(out, "   Title: %s\n", s.Title)
+			fmt.Fprintf(out, "   Language: %s\n", s.Language)
+			fmt.Fprintf(out, "   Tags: %s\n", strings.Join(s.Tags, ", "))
+			fmt.Fprintf(out, "   Description: %s\n", s.Description)
+			fmt.Fprintf(out, "   Executable: %t\n", s.Executable)
+			fmt.Fprintf(out, "   Used: %d times\n", s.UsageCount)
 
 			if expanded {
-				fmt.Println("   Code:")
-				fmt.Println("   ─────────────────────────────────────")
+				fmt.Fprintln(out, "   Code:")
+				fmt.Fprintln(out, "   ─────────────────────────────────────")
 				// Indent each line of code
-				codeLines := strings.Split(s.Code, "\n")
+				codeLines := strings.Split(highlightCode(s.Code, s.Language), "\n")
 				for _, line := range codeLines {
-					fmt.Printf("   %s\n", line)
+					fmt.Fprintf(out, "   %s\n", line)
 				}
-				fmt.Println("   ─────────────────────────────────────")
-				fmt.Println("   last used:", s.LastUsed)
-				fmt.Println("   created at:", s.CreatedAt)
+				fmt.Fprintln(out, "   ─────────────────────────────────────")
+				fmt.Fprintln(out, "   last used:", s.LastUsed)
+				fmt.Fprintln(out, "   created at:", s.CreatedAt)
 
 			}
-			fmt.Println()
+			fmt.Fprintln(out)
 		}
 	},
 }
 
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
 func init() {
 	listCmd.Flags().StringP("language", "l", "", "Filter by language")
-	listCmd.Flags().StringP("tag", "t", "", "Filter by tag")
+	listCmd.Flags().StringP("tag", "t", "", "Filter by tag, including nested tags such as tag/child")
+	listCmd.Flags().StringP("collection", "c", "", "Show only snippets in a saved collection")
 	listCmd.Flags().BoolP("expanded", "e", false, "Show code content for each snippet")
+	addListingFlags(listCmd)
+	addFormatFlag(listCmd)
+	addTableFlags(listCmd)
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// addListingFlags registers the sorting, pagination and pager flags shared by
+// list and search.
+func addListingFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("sort", "s", "", "Sort by "+strings.Join(query.SortKeys, ", ")+" (append :asc or :desc)")
+	cmd.Flags().IntP("limit", "n", 0, "Show at most this many snippets")
+	cmd.Flags().Int("offset", 0, "Skip this many snippets")
+	cmd.Flags().Bool("no-pager", false, "Do not pipe long output through $PAGER")
+}
+
+// sortAndPaginate applies the --sort, --offset and --limit flags to snippets
+// and returns the offset that was used. defaultSort is used when --sort is
+// not given; an empty defaultSort keeps insertion order.
+func sortAndPaginate(cmd *cobra.Command, snippets *[]snippet.Snippet, defaultSort string) (int, error) {
+	sortBy, _ := cmd.Flags().GetString("sort")
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+
+	if limit < 0 || offset < 0 {
+		return 0, fmt.Errorf("--limit and --offset cannot be negative")
+	}
+
+	if sortBy != "" {
+		spec, err := query.ParseSort(sortBy)
+		if err != nil {
+			return 0, err
+		}
+		var scores map[string]float64
+		if spec.Key == "frecency" {
+			scores = loadFrecency(*snippets)
+		}
+		query.Sort(*snippets, spec, scores)
+	}
+
+	*snippets = query.Paginate(*snippets, offset, limit)
+	return offset, nil
+}
+
+// pageInfo describes which slice of the results is shown, or nothing when
+// every result is shown.
+func pageInfo(offset, shown, total int) string {
+	if offset == 0 && shown == total {
+		return ""
+	}
+	return fmt.Sprintf(", showing %d-%d", offset+1, offset+shown)
+}
+
+// loadFrecency computes frecency scores for snippets from the usage log. If
+// the log cannot be read, scores are estimated from the usage counters.
+func loadFrecency(snippets []snippet.Snippet) map[string]float64 {
+	events, err := store.LoadUsageEvents()
+	if err != nil {
+		warnf("Failed to load usage history: %v", err)
+		events = nil
+	}
+	return snippet.FrecencyScores(snippets, events, time.Now())
+}
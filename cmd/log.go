@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the journal of changes that can be undone",
+	Long: `Show the most recent changes to your snippets, newest first. Entries
+marked (undone) can be restored with 'codestash redo'.
+
+The journal is kept in ~/.codestash/journal.jsonl and holds the last 200
+changes; set CODESTASH_JOURNAL_SIZE to keep more or fewer.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		if limit < 0 {
+			failf("invalid_argument", "--limit cannot be negative")
+			return
+		}
+
+		entries, err := store.LoadJournal()
+		if err != nil {
+			failf("load_failed", "Failed to load the journal: %v", err)
+			return
+		}
+
+		// Newest first
+		recent := []snippet.JournalEntry{}
+		for i := len(entries) - 1; i >= 0 && (limit == 0 || len(recent) < limit); i-- {
+			recent = append(recent, entries[i])
+		}
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, recent); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if len(recent) == 0 {
+			noticef("📭", "No changes recorded yet")
+			return
+		}
+
+		ids := make([]string, len(entries))
+		for i, e := range entries {
+			ids[i] = e.ID
+		}
+		short := snippet.ShortIDs(ids)
+
+		for _, e := range recent {
+			when := e.Time
+			if t, err := time.Parse(time.RFC3339, e.Time); err == nil {
+				when = formatTimeAgo(t)
+			}
+			status := ""
+			if e.Undone {
+				status = " (undone)"
+			}
+			fmt.Printf("%s%s  %s%s\n", emoji("📝"), short[e.ID], e.Summary, status)
+			fmt.Printf("   %s — %s\n", when, e.Command)
+			if verbose {
+				for _, c := range e.Changes {
+					fmt.Printf("   %s\n", describeSnippetChange(c))
+				}
+			}
+		}
+	},
+}
+
+// describeSnippetChange renders one change of a journal entry as a line:
+// + for an added snippet, - for a deleted one and ~ for an edit.
+func describeSnippetChange(c snippet.SnippetChange) string {
+	switch {
+	case c.Before == nil:
+		return fmt.Sprintf("+ %s  %s", shortID(c.ID), c.After.Title)
+	case c.After == nil:
+		return fmt.Sprintf("- %s  %s", shortID(c.ID), c.Before.Title)
+	}
+	return fmt.Sprintf("~ %s  %s (%s)", shortID(c.ID), c.After.Title, strings.Join(snippet.ChangedFields(*c.Before, *c.After), ", "))
+}
+
+func init() {
+	logCmd.Flags().IntP("limit", "n", 20, "Show at most this many changes (0 for all)")
+	logCmd.Flags().BoolP("verbose", "v", false, "List the snippets each change touched")
+}
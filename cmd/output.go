@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by the global --output flag.
+const (
+	outputText   = "text"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputYAML   = "yaml"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputNDJSON, outputYAML}
+
+var outputFormat = outputText
+
+// ErrReported is returned by Execute when a command failed and has already
+// reported the failure to the user.
+var ErrReported = errors.New("command failed")
+
+// commandFailed is set by failf so Execute can return ErrReported.
+var commandFailed bool
+
+func validateOutputFormat() error {
+	outputFormat = strings.ToLower(strings.TrimSpace(outputFormat))
+	for _, f := range outputFormats {
+		if f == outputFormat {
+			return nil
+		}
+	}
+	format := outputFormat
+	outputFormat = outputText
+	return fmt.Errorf("unknown output format '%s'. Valid formats: %s", format, strings.Join(outputFormats, ", "))
+}
+
+// structuredOutput reports whether a machine-readable format was requested.
+func structuredOutput() bool {
+	return outputFormat != outputText
+}
+
+// writeOutput writes a single document in the selected format.
+func writeOutput(w io.Writer, v any) error {
+	switch outputFormat {
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	case outputNDJSON:
+		return json.NewEncoder(w).Encode(v)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+}
+
+// writeOutputList writes items as a JSON array, a YAML sequence, or one JSON
+// object per line for ndjson. An empty list is written as [] (nothing for
+// ndjson).
+func writeOutputList[T any](w io.Writer, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	if outputFormat != outputNDJSON {
+		return writeOutput(w, items)
+	}
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snippetDocs prepares snippets for structured output so that list fields are
+// always arrays rather than null.
+func snippetDocs(snippets []snippet.Snippet) []snippet.Snippet {
+	docs := make([]snippet.Snippet, len(snippets))
+	for i, s := range snippets {
+		if s.Tags == nil {
+			s.Tags = []string{}
+		}
+		docs[i] = s
+	}
+	return docs
+}
+
+// errorDoc is the structured error written to stderr in machine-readable
+// output modes.
+type errorDoc struct {
+	Error errorBody `json:"error" yaml:"error"`
+}
+
+type errorBody struct {
+	Code       string         `json:"code" yaml:"code"`
+	Message    string         `json:"message" yaml:"message"`
+	Candidates []candidateDoc `json:"candidates,omitempty" yaml:"candidates,omitempty"`
+}
+
+type candidateDoc struct {
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+}
+
+func writeStructuredError(body errorBody) {
+	if outputFormat == outputYAML {
+		_ = yaml.NewEncoder(os.Stderr).Encode(errorDoc{Error: body})
+		return
+	}
+	_ = json.NewEncoder(os.Stderr).Encode(errorDoc{Error: body})
+}
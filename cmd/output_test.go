@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// runEnv marks a re-executed test binary that should run codestash itself.
+// The store path is fixed from $HOME when the program starts, so every run
+// needs a fresh process.
+const runEnv = "CODESTASH_TEST_RUN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runEnv) == "1" {
+		if err := Execute(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// TestStructuredOutput locks down the --output schemas of list, search,
+// print, stats and collection list, and the error objects written to stderr.
+func TestStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"list_json", []string{"list", "--output", "json"}, false},
+		{"list_ndjson", []string{"list", "--output", "ndjson"}, false},
+		{"list_yaml", []string{"list", "--output", "yaml"}, false},
+		{"list_empty_json", []string{"list", "--tag", "nothing", "--output", "json"}, false},
+		{"search_json", []string{"search", "git", "--output", "json"}, false},
+		{"search_ndjson", []string{"search", "git", "--output", "ndjson"}, false},
+		{"search_yaml", []string{"search", "git", "--output", "yaml"}, false},
+		{"print_json", []string{"print", "docker build", "--output", "json"}, false},
+		{"print_ndjson", []string{"print", "docker build", "--output", "ndjson"}, false},
+		{"print_yaml", []string{"print", "docker build", "--output", "yaml"}, false},
+		{"stats_json", []string{"stats", "--output", "json"}, false},
+		{"stats_ndjson", []string{"stats", "--output", "ndjson"}, false},
+		{"stats_yaml", []string{"stats", "--output", "yaml"}, false},
+		{"collection_list_json", []string{"collection", "list", "--output", "json"}, false},
+		{"collection_list_ndjson", []string{"collection", "list", "--output", "ndjson"}, false},
+		{"collection_list_yaml", []string{"collection", "list", "--output", "yaml"}, false},
+		{"error_not_found_json", []string{"print", "nope", "--output", "json"}, true},
+		{"error_not_found_yaml", []string{"print", "nope", "--output", "yaml"}, true},
+		{"error_ambiguous_json", []string{"print", "ab", "--output", "json"}, true},
+		{"error_ambiguous_ndjson", []string{"print", "ab", "--output", "ndjson"}, true},
+		{"error_usage_json", []string{"--output", "json", "list", "--bogus"}, true},
+		{"error_invalid_argument_json", []string{"list", "--color", "bad", "--output", "json"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, err := runCodestash(t, tt.args...)
+			got, other := stdout, stderr
+			if tt.wantErr {
+				var exitErr *exec.ExitError
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("codestash %s: want a failing exit status, got %v", strings.Join(tt.args, " "), err)
+				}
+				got, other = stderr, stdout
+			} else if err != nil {
+				t.Fatalf("codestash %s: %v\nstderr: %s", strings.Join(tt.args, " "), err, stderr)
+			}
+			if other != "" {
+				t.Errorf("unexpected output on the other stream:\n%s", other)
+			}
+
+			checkGolden(t, tt.name, scrubOutput(got))
+		})
+	}
+}
+
+// runCodestash runs codestash with args against a copy of the fixture store
+// in testdata/store.
+func runCodestash(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	home := t.TempDir()
+	dir := filepath.Join(home, ".codestash")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"snippets.json", "usage.jsonl", "collections.json"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "store", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), runEnv+"=1", "HOME="+home, "CODESTASH_THEME=", "NO_COLOR=1")
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err = cmd.Run()
+	return out.String(), errOut.String(), err
+}
+
+var (
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`)
+	frecencyPattern  = regexp.MustCompile(`("?frecency"?:\s*)[0-9.eE+-]+`)
+	testStarted      = time.Now().Add(-time.Minute)
+)
+
+// scrubOutput replaces the values that depend on when the test runs:
+// timestamps written by the command itself, and frecency scores, which decay
+// with time.
+func scrubOutput(s string) string {
+	s = timestampPattern.ReplaceAllStringFunc(s, func(ts string) string {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil && t.After(testStarted) {
+			return "<now>"
+		}
+		return ts
+	})
+	return frecencyPattern.ReplaceAllString(s, "${1}<frecency>")
+}
+
+// checkGolden compares got with testdata/<name>.golden, or rewrites the file
+// with -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run 'go test ./cmd -update' to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match %s (run 'go test ./cmd -update' if the change is intended)\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
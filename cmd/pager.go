@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// pagedOutput buffers command output and, when it is flushed, sends it
+// through $PAGER if it would not fit on the terminal.
+type pagedOutput struct {
+	bytes.Buffer
+	enabled bool
+}
+
+func newPagedOutput(cmd *cobra.Command) *pagedOutput {
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+	return &pagedOutput{enabled: !noPager}
+}
+
+// Flush writes the buffered output to stdout, through the pager if needed.
+func (p *pagedOutput) Flush() error {
+	defer p.Reset()
+
+	fd := int(os.Stdout.Fd())
+	if !p.enabled || !term.IsTerminal(fd) {
+		_, err := os.Stdout.Write(p.Bytes())
+		return err
+	}
+
+	_, height, err := term.GetSize(fd)
+	if err != nil || bytes.Count(p.Bytes(), []byte("\n")) < height {
+		_, err := os.Stdout.Write(p.Bytes())
+		return err
+	}
+
+	pager := pagerCommand()
+	if pager == nil {
+		_, err := os.Stdout.Write(p.Bytes())
+		return err
+	}
+	pager.Stdin = bytes.NewReader(p.Bytes())
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if err := pager.Run(); err != nil {
+		_, err := os.Stdout.Write(p.Bytes())
+		return err
+	}
+	return nil
+}
+
+// pagerCommand builds the command for $PAGER, falling back to less or more.
+// It returns nil when no pager is available or PAGER is set to "cat".
+func pagerCommand() *exec.Cmd {
+	if pager := strings.TrimSpace(os.Getenv("PAGER")); pager != "" {
+		if pager == "cat" {
+			return nil
+		}
+		parts := strings.Fields(pager)
+		return exec.Command(parts[0], parts[1:]...)
+	}
+
+	if _, err := exec.LookPath("less"); err == nil {
+		return exec.Command("less", "-FRX")
+	}
+	if runtime.GOOS == "windows" {
+		return exec.Command("more")
+	}
+	if _, err := exec.LookPath("more"); err == nil {
+		return exec.Command("more")
+	}
+	return nil
+}
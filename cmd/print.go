@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"os"
 	"time"
 
 	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
@@ -17,44 +18,87 @@ var printCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
 		// Find snippet by ID or title
-		targetSnippet := findSnippet(snippets, args[0])
-		if targetSnippet == nil {
-			fmt.Printf("❌ Snippet '%s' not found\n", args[0])
+		targetSnippet, err := findSnippet(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
 
 		// Update usage stats
-		updateUsageStats(targetSnippet)
+		updateUsageStats(targetSnippet, snippet.UsagePrint)
 
 		// Save updated stats
 		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("⚠️  Failed to update usage stats:", err)
+			warnf("Failed to update usage stats: %v", err)
+		}
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, snippetDocs([]snippet.Snippet{*targetSnippet})[0]); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		// Print only the code when piped or with --raw
+		if rawOutput(cmd) {
+			writeRawCode(targetSnippet.Code)
+			return
 		}
 
 		// Print the snippet
-		fmt.Printf("📄 %s\n", targetSnippet.Title)
-		fmt.Printf("📝 %s\n", targetSnippet.Description)
+		fmt.Printf("%s%s\n", emoji("📄"), targetSnippet.Title)
+		fmt.Printf("%s%s\n", emoji("📝"), targetSnippet.Description)
 		fmt.Println("─────────────────────────────────────")
-		fmt.Println(targetSnippet.Code)
+		fmt.Println(highlightCode(targetSnippet.Code, targetSnippet.Language))
 		fmt.Println("─────────────────────────────────────")
 	},
 }
 
-func findSnippet(snippets []snippet.Snippet, query string) *snippet.Snippet {
-	for i, s := range snippets {
-		if s.ID == query || strings.EqualFold(s.Title, query) {
-			return &snippets[i]
+func init() {
+	printCmd.Flags().BoolP("raw", "r", false, "Print only the code (default when stdout is not a terminal)")
+}
+
+func findSnippet(snippets []snippet.Snippet, query string) (*snippet.Snippet, error) {
+	idx, err := snippet.Resolve(snippets, query)
+	if err != nil {
+		return nil, err
+	}
+	return &snippets[idx], nil
+}
+
+func printResolveError(query string, err error) {
+	var ambiguous *snippet.AmbiguousError
+	if errors.As(err, &ambiguous) && structuredOutput() {
+		body := errorBody{Code: "ambiguous", Message: ambiguous.Error()}
+		for _, c := range ambiguous.Candidates {
+			body.Candidates = append(body.Candidates, candidateDoc{ID: c.ID, Title: c.Title})
 		}
+		reportError(body)
+		return
 	}
-	return nil
+	if errors.As(err, &ambiguous) {
+		failf("ambiguous", "Snippet reference '%s' is ambiguous (%d matches by %s):", query, len(ambiguous.Candidates), ambiguous.Stage)
+		for _, c := range ambiguous.Candidates {
+			fmt.Fprintf(os.Stderr, "   • %s  %s (%s)\n", shortID(c.ID), c.Title, c.Language)
+		}
+		hintf("Use one of the IDs above to pick one")
+		return
+	}
+	failf("not_found", "Snippet '%s' not found", query)
 }
 
-func updateUsageStats(s *snippet.Snippet) {
+// updateUsageStats bumps the usage counters of s and records the use in the
+// usage log. command is one of the snippet.Usage* constants.
+func updateUsageStats(s *snippet.Snippet, command string) {
 	s.UsageCount++
 	s.LastUsed = time.Now().UTC().Format(time.RFC3339)
+
+	if err := store.RecordUsage(snippet.NewUsageEvent(s.ID, command)); err != nil {
+		warnf("Failed to record usage: %v", err)
+	}
 }
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
+	"github.com/AngeloMihaelle/CodeStash/internal/render"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render [query]",
+	Short: "Render snippets as a Markdown or HTML cheatsheet",
+	Long: `Render snippets as a Markdown or HTML cheatsheet with a table of contents.
+
+The HTML page is self-contained: code is highlighted with inline styles and
+a filter box hides snippets that do not match. An optional query (the same
+syntax as 'codestash search') limits which snippets are included.
+
+Examples:
+  codestash render -o cheatsheet.md
+  codestash render --format html --group-by tag -o cheatsheet.html
+  codestash render "tag:k8s" --group-by none`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		outPath, _ := cmd.Flags().GetString("out")
+		title, _ := cmd.Flags().GetString("title")
+		collectionName, _ := cmd.Flags().GetString("collection")
+
+		// Pick the format from the file extension when not given
+		if !cmd.Flags().Changed("format") {
+			switch strings.ToLower(filepath.Ext(outPath)) {
+			case ".html", ".htm":
+				format = render.FormatHTML
+			}
+		}
+
+		if collectionName != "" {
+			snippets, err = filterByCollection(snippets, collectionName)
+			if err != nil {
+				failf("collection", "%v", err)
+				return
+			}
+		}
+		if len(args) == 1 {
+			q, err := query.Parse(args[0])
+			if err != nil {
+				failf("invalid_query", "Invalid query: %v", err)
+				return
+			}
+			snippets = q.Filter(snippets)
+		}
+
+		if len(snippets) == 0 {
+			warnf("No snippets to render, the cheatsheet will be empty")
+		}
+
+		var buf bytes.Buffer
+		opts := render.Options{Title: title, GroupBy: groupBy, Theme: colorTheme, Generated: time.Now()}
+		if err := render.Render(&buf, format, snippets, opts); err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+
+		if outPath == "" || outPath == "-" {
+			if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+				failf("output_failed", "Failed to write cheatsheet: %v", err)
+			}
+			return
+		}
+
+		if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+			failf("save_failed", "Failed to write cheatsheet: %v", err)
+			return
+		}
+		successf("Rendered %d snippet(s) to %s", len(snippets), outPath)
+	},
+}
+
+func init() {
+	renderCmd.Flags().StringP("format", "f", render.FormatMarkdown, "Cheatsheet format: "+strings.Join(render.Formats, " or ")+"; an --out file ending in .html defaults to html")
+	renderCmd.Flags().StringP("group-by", "g", render.GroupByLanguage, "Group snippets by "+strings.Join(render.Groupings, ", "))
+	renderCmd.Flags().StringP("out", "o", "", "Write the cheatsheet to a file instead of stdout")
+	renderCmd.Flags().String("title", "", "Page title (default \"CodeStash Cheatsheet\")")
+	renderCmd.Flags().StringP("collection", "c", "", "Render only snippets in a saved collection")
+}
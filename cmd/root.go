@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"errors"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
@@ -8,22 +11,84 @@ var rootCmd = &cobra.Command{
 	Use:   "codestash",
 	Short: "🧰 CodeStash - Your local code snippet manager",
 	Long:  "CodeStash is a local-first CLI tool to manage and execute code snippets efficiently.",
+	// Errors and usage are reported by Execute so they follow --plain and
+	// --output
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(); err != nil {
+			return &codedError{code: "invalid_argument", err: err}
+		}
+		detectPlainMode(cmd)
+		if err := validateColorFlags(); err != nil {
+			return &codedError{code: "invalid_argument", err: err}
+		}
+		return nil
+	},
+}
+
+// codedError is returned from cobra hooks for failures that are not usage
+// mistakes, so Execute can report them with their own error code.
+type codedError struct {
+	code string
+	err  error
 }
 
+func (e *codedError) Error() string { return e.err.Error() }
+
 func Execute() error {
-	return rootCmd.Execute()
+	// Errors in the flags themselves are reported before PersistentPreRunE
+	detectPlainMode(nil)
+	cmd, err := rootCmd.ExecuteC()
+	var coded *codedError
+	switch {
+	case errors.As(err, &coded):
+		failf(coded.code, "%v", coded.err)
+		return ErrReported
+	case err != nil:
+		// Anything else from cobra is a flag, argument or command name
+		// mistake. Usage text would corrupt a structured error on stderr
+		if !structuredOutput() {
+			cmd.PrintErrln(cmd.UsageString())
+		}
+		reportError(errorBody{Code: "usage", Message: err.Error()})
+		return ErrReported
+	}
+	if commandFailed {
+		return ErrReported
+	}
+	return nil
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "Output format: text, json, ndjson or yaml")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Highlight code: auto, always or never")
+	rootCmd.PersistentFlags().StringVar(&colorTheme, "theme", os.Getenv("CODESTASH_THEME"), "Syntax highlighting theme (see 'codestash themes')")
+	rootCmd.PersistentFlags().Bool("plain", false, "No emoji or colors (default when output is not a terminal)")
+	rootCmd.PersistentFlags().StringVar(&colorPalette, "palette", "auto", "Color palette: auto, 16, 256 or truecolor")
+
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(useCmd)
 	rootCmd.AddCommand(deleteCmd)
-	// 	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(treeCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(execCmd)
 	rootCmd.AddCommand(copyCmd)
 	rootCmd.AddCommand(printCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(dupesCmd)
+	rootCmd.AddCommand(collectionCmd)
+	rootCmd.AddCommand(themesCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(doctorCmd)
 }
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/AngeloMihaelle/CodeStash/internal/query"
 	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
 	"github.com/spf13/cobra"
@@ -12,72 +14,134 @@ import (
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search snippets by title, description, tags, or content",
-	Args:  cobra.ExactArgs(1),
+	Long: `Search snippets by title, description, tags, or content.
+
+The query may combine free text with field terms, all of which must match:
+  tag:<tag>        snippet has the tag
+  lang:<language>  snippet language
+  exec:true|false  executable status
+  title:<text>     title contains text
+  id:<prefix>      ID starts with prefix
+  desc:<text>      description contains text
+  code:<text>      code contains text
+
+Prefix a field term with '-' to negate it, e.g. -tag:deprecated. Put '--'
+before a query that starts with '-': codestash search -- "-tag:old"`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
-		query := strings.ToLower(args[0])
-		var matches []snippet.Snippet
+		tmpl, err := parseFormatFlag(cmd)
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+		quiet := structuredOutput() || tmpl != nil
+
+		q, err := query.Parse(args[0])
+		if err != nil {
+			failf("invalid_query", "Invalid query: %v", err)
+			return
+		}
 
 		// Get filter flags
 		executable, _ := cmd.Flags().GetBool("executable")
+		collectionName, _ := cmd.Flags().GetString("collection")
 
-		for _, s := range snippets {
-			if matchesQuery(s, query) {
-				// Apply executable filter if specified
-				if executable && !s.Executable {
-					continue
-				}
-				matches = append(matches, s)
+		if collectionName != "" {
+			snippets, err = filterByCollection(snippets, collectionName)
+			if err != nil {
+				failf("collection", "%v", err)
+				return
+			}
+		}
+
+		var matches []snippet.Snippet
+		for _, s := range q.Filter(snippets) {
+			// Apply executable filter if specified
+			if executable && !s.Executable {
+				continue
 			}
+			matches = append(matches, s)
+		}
+
+		if len(matches) == 0 && !quiet {
+			noticef("🔍", "No snippets found matching '%s'", args[0])
+			return
 		}
 
+		// Apply sorting and pagination, ranking by frecency by default
+		total := len(matches)
+		offset, err := sortAndPaginate(cmd, &matches, "frecency")
+		if err != nil {
+			failf("invalid_argument", "%v", err)
+			return
+		}
+
+		if structuredOutput() {
+			if err := writeOutputList(os.Stdout, snippetDocs(matches)); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if tmpl != nil {
+			out := newPagedOutput(cmd)
+			defer out.Flush()
+			if err := writeFormatted(out, tmpl, matches, q.Text); err != nil {
+				failf("invalid_argument", "Failed to apply --format: %v", err)
+			}
+			return
+		}
 		if len(matches) == 0 {
-			fmt.Printf("🔍 No snippets found matching '%s'\n", args[0])
+			noticef("🔍", "No snippets past offset %d (%d match '%s')", offset, total, args[0])
 			return
 		}
 
 		// Get expanded flag
 		expanded, _ := cmd.Flags().GetBool("expanded")
 
-		fmt.Printf("🔍 Found %d snippet(s) matching '%s':\n\n", len(matches), args[0])
+		out := newPagedOutput(cmd)
+		defer out.Flush()
+
+		fmt.Fprintf(out, "%sFound %d snippet(s) matching '%s'%s:\n\n", emoji("🔍"), total, args[0], pageInfo(offset, len(matches), total))
 
 		for _, s := range matches {
-			fmt.Printf("🔹 ID: %s\n", s.ID)
-			fmt.Printf("   Title: %s\n", s.Title)
-			fmt.Printf("   Language: %s\n", s.Language)
-			fmt.Printf("   Tags: %s\n", strings.Join(s.Tags, ", "))
-			fmt.Printf("   Description: %s\n", s.Description)
-			fmt.Printf("   Used: %d times\n", s.UsageCount)
+			fmt.Fprintf(out, "%sID: %s\n", emoji("🔹"), shortID(s.ID))
+			fmt.Fprintf(out, "   Title: %s\n", s.Title)
+			fmt.Fprintf(out, "   Language: %s\n", s.Language)
+			fmt.Fprintf(out, "   Tags: %s\n", strings.Join(s.Tags, ", "))
+			fmt.Fprintf(out, "   Description: %s\n", s.Description)
+			fmt.Fprintf(out, "   Used: %d times\n", s.UsageCount)
 
 			// Show executable status
 			if s.Executable {
-				fmt.Println("   🚀 Executable: Yes")
+				fmt.Fprintln(out, "   "+emoji("🚀")+"Executable: Yes")
 			} else {
-				fmt.Println("   📄 Executable: No")
+				fmt.Fprintln(out, "   "+emoji("📄")+"Executable: No")
 			}
 
 			if expanded {
-				fmt.Println("   Code:")
-				fmt.Println("   ─────────────────────────────────────")
+				fmt.Fprintln(out, "   Code:")
+				fmt.Fprintln(out, "   ─────────────────────────────────────")
 				// Indent each line of code
-				codeLines := strings.Split(s.Code, "\n")
+				codeLines := strings.Split(highlightCode(s.Code, s.Language), "\n")
 				for _, line := range codeLines {
-					fmt.Printf("   %s\n", line)
+					fmt.Fprintf(out, "   %s\n", line)
 				}
-				fmt.Println("   ─────────────────────────────────────")
+				fmt.Fprintln(out, "   ─────────────────────────────────────")
 			} else {
 				// Show code preview if it matches (only when not expanded)
-				if strings.Contains(strings.ToLower(s.Code), query) {
-					preview := getCodePreview(s.Code, query)
-					fmt.Printf("   Preview: %s\n", preview)
+				if q.Text != "" && strings.Contains(strings.ToLower(s.Code), q.Text) {
+					preview := getCodePreview(s.Code, q.Text)
+					fmt.Fprintf(out, "   Preview: %s\n", preview)
 				}
 			}
-			fmt.Println()
+			fmt.Fprintln(out)
 		}
 	},
 }
@@ -85,42 +149,9 @@ var searchCmd = &cobra.Command{
 func init() {
 	searchCmd.Flags().BoolP("expanded", "e", false, "Show full code content for each snippet")
 	searchCmd.Flags().BoolP("executable", "x", false, "Show only executable snippets")
-}
-
-func matchesQuery(s snippet.Snippet, query string) bool {
-	// Check ID
-	if strings.Contains(strings.ToLower(s.ID), query) {
-		return true
-	}
-
-	// Check title
-	if strings.Contains(strings.ToLower(s.Title), query) {
-		return true
-	}
-
-	// Check description
-	if strings.Contains(strings.ToLower(s.Description), query) {
-		return true
-	}
-
-	// Check tags
-	for _, tag := range s.Tags {
-		if strings.Contains(strings.ToLower(tag), query) {
-			return true
-		}
-	}
-
-	// Check code content
-	if strings.Contains(strings.ToLower(s.Code), query) {
-		return true
-	}
-
-	// Check language
-	if strings.Contains(strings.ToLower(s.Language), query) {
-		return true
-	}
-
-	return false
+	searchCmd.Flags().StringP("collection", "c", "", "Search only within a saved collection")
+	addListingFlags(searchCmd)
+	addFormatFlag(searchCmd)
 }
 
 func getCodePreview(code, query string) string {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"time"
 
@@ -16,37 +17,109 @@ var statsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
-		if len(snippets) == 0 {
-			fmt.Println("📭 No snippets found. Use 'codestash add' to create your first snippet!")
+		if len(snippets) == 0 && !structuredOutput() {
+			noticef("📭", "No snippets found. Use 'codestash add' to create your first snippet!")
 			return
 		}
 
 		// Get detailed flag
 		detailed, _ := cmd.Flags().GetBool("detailed")
 
-		displayStats(snippets, detailed)
+		events, err := store.LoadUsageEvents()
+		if err != nil {
+			warnf("Failed to load usage history: %v", err)
+		}
+
+		report := buildStatsReport(snippets, events)
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, report); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		displayStats(report, detailed)
 	},
 }
 
-func displayStats(snippets []snippet.Snippet, detailed bool) {
-	fmt.Printf("📊 CodeStash Statistics\n")
-	fmt.Printf("═══════════════════════════════════════\n\n")
+// statsReport holds everything shown by 'codestash stats'. Its JSON and YAML
+// forms are the documented --output schema for the command.
+type statsReport struct {
+	TotalSnippets      int            `json:"total_snippets" yaml:"total_snippets"`
+	ExecutableSnippets int            `json:"executable_snippets" yaml:"executable_snippets"`
+	TotalUsage         int            `json:"total_usage" yaml:"total_usage"`
+	AverageUsage       float64        `json:"average_usage" yaml:"average_usage"`
+	UsageByCommand     map[string]int `json:"usage_by_command" yaml:"usage_by_command"`
+	MostUsed           []statsEntry   `json:"most_used" yaml:"most_used"`
+	Trending           []statsEntry   `json:"trending" yaml:"trending"`
+	RecentlyCreated    []statsEntry   `json:"recently_created" yaml:"recently_created"`
+	RecentlyUsed       []statsEntry   `json:"recently_used" yaml:"recently_used"`
+	Unused             []statsEntry   `json:"unused" yaml:"unused"`
+	Languages          []statsCount   `json:"languages" yaml:"languages"`
+	Tags               []statsCount   `json:"tags" yaml:"tags"`
+	TagRollups         []statsCount   `json:"tag_rollups" yaml:"tag_rollups"`
+}
+
+type statsEntry struct {
+	ID         string  `json:"id" yaml:"id"`
+	Title      string  `json:"title" yaml:"title"`
+	Language   string  `json:"language" yaml:"language"`
+	UsageCount int     `json:"usage_count" yaml:"usage_count"`
+	Frecency   float64 `json:"frecency" yaml:"frecency"`
+	LastUsed   string  `json:"last_used" yaml:"last_used"`
+	CreatedAt  string  `json:"created_at" yaml:"created_at"`
+}
+
+type statsCount struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+func buildStatsReport(snippets []snippet.Snippet, events []snippet.UsageEvent) statsReport {
+	scores := snippet.FrecencyScores(snippets, events, time.Now())
+	entry := func(s snippet.Snippet) statsEntry {
+		return statsEntry{
+			ID:         s.ID,
+			Title:      s.Title,
+			Language:   s.Language,
+			UsageCount: s.UsageCount,
+			Frecency:   scores[s.ID],
+			LastUsed:   s.LastUsed,
+			CreatedAt:  s.CreatedAt,
+		}
+	}
+	top := func(sorted []snippet.Snippet, n int) []statsEntry {
+		entries := []statsEntry{}
+		for i := 0; i < len(sorted) && i < n; i++ {
+			entries = append(entries, entry(sorted[i]))
+		}
+		return entries
+	}
+
+	report := statsReport{
+		TotalSnippets: len(snippets),
+		UsageByCommand: map[string]int{
+			snippet.UsagePrint: 0,
+			snippet.UsageCopy:  0,
+			snippet.UsageExec:  0,
+		},
+		Unused: []statsEntry{},
+	}
 
-	// Basic stats
-	totalSnippets := len(snippets)
-	totalUsage := 0
-	executableCount := 0
 	languageCount := make(map[string]int)
 	tagCount := make(map[string]int)
 
 	for _, s := range snippets {
-		totalUsage += s.UsageCount
+		report.TotalUsage += s.UsageCount
 		if s.Executable {
-			executableCount++
+			report.ExecutableSnippets++
+		}
+		if s.UsageCount == 0 {
+			report.Unused = append(report.Unused, entry(s))
 		}
 		languageCount[s.Language]++
 		for _, tag := range s.Tags {
@@ -54,33 +127,108 @@ func displayStats(snippets []snippet.Snippet, detailed bool) {
 		}
 	}
 
-	fmt.Printf("📚 Total Snippets: %d\n", totalSnippets)
-	fmt.Printf("🚀 Executable Snippets: %d\n", executableCount)
-	fmt.Printf("📋 Non-executable Snippets: %d\n", totalSnippets-executableCount)
-	fmt.Printf("📈 Total Usage: %d times\n", totalUsage)
+	if report.TotalSnippets > 0 {
+		report.AverageUsage = float64(report.TotalUsage) / float64(report.TotalSnippets)
+	}
 
-	if totalSnippets > 0 {
-		avgUsage := float64(totalUsage) / float64(totalSnippets)
-		fmt.Printf("📊 Average Usage: %.1f times per snippet\n", avgUsage)
+	for _, e := range events {
+		report.UsageByCommand[e.Command]++
 	}
 
 	// Most used snippets
-	fmt.Printf("\n🏆 Top 5 Most Used Snippets:\n")
-	fmt.Printf("───────────────────────────────────────\n")
-
 	sortedByUsage := make([]snippet.Snippet, len(snippets))
 	copy(sortedByUsage, snippets)
-	sort.Slice(sortedByUsage, func(i, j int) bool {
+	sort.SliceStable(sortedByUsage, func(i, j int) bool {
 		return sortedByUsage[i].UsageCount > sortedByUsage[j].UsageCount
 	})
+	report.MostUsed = top(sortedByUsage, 5)
+
+	// Highest frecency snippets
+	var sortedByFrecency []snippet.Snippet
+	for _, s := range snippets {
+		if scores[s.ID] > 0 {
+			sortedByFrecency = append(sortedByFrecency, s)
+		}
+	}
+	sort.SliceStable(sortedByFrecency, func(i, j int) bool {
+		return scores[sortedByFrecency[i].ID] > scores[sortedByFrecency[j].ID]
+	})
+	report.Trending = top(sortedByFrecency, 5)
 
-	topCount := 5
-	if len(sortedByUsage) < 5 {
-		topCount = len(sortedByUsage)
+	// Recently created snippets
+	sortedByCreated := make([]snippet.Snippet, len(snippets))
+	copy(sortedByCreated, snippets)
+	sort.SliceStable(sortedByCreated, func(i, j int) bool {
+		return sortedByCreated[i].CreatedAt > sortedByCreated[j].CreatedAt
+	})
+	report.RecentlyCreated = top(sortedByCreated, 3)
+
+	// Recently used snippets
+	var recentlyUsed []snippet.Snippet
+	for _, s := range snippets {
+		if s.LastUsed != "" {
+			recentlyUsed = append(recentlyUsed, s)
+		}
 	}
+	sort.SliceStable(recentlyUsed, func(i, j int) bool {
+		return recentlyUsed[i].LastUsed > recentlyUsed[j].LastUsed
+	})
+	report.RecentlyUsed = top(recentlyUsed, 3)
+
+	report.Languages = sortedCounts(languageCount)
+	report.Tags = sortedCounts(tagCount)
+
+	// Roll hierarchical tags up into their parents, e.g. cloud/aws/s3 into
+	// cloud/aws and cloud, counting each snippet once per parent
+	rollups := make(map[string]int)
+	var walk func(nodes []*snippet.TagNode)
+	walk = func(nodes []*snippet.TagNode) {
+		for _, n := range nodes {
+			if len(n.Children) > 0 {
+				rollups[n.Path] = n.Count
+				walk(n.Children)
+			}
+		}
+	}
+	walk(snippet.TagTree(snippets))
+	report.TagRollups = sortedCounts(rollups)
+	return report
+}
 
-	for i := 0; i < topCount; i++ {
-		s := sortedByUsage[i]
+// sortedCounts orders counts from most to least common, breaking ties by name.
+func sortedCounts(counts map[string]int) []statsCount {
+	stats := []statsCount{}
+	for name, count := range counts {
+		stats = append(stats, statsCount{name, count})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+func displayStats(report statsReport, detailed bool) {
+	fmt.Printf("%sCodeStash Statistics\n", emoji("📊"))
+	fmt.Printf("═══════════════════════════════════════\n\n")
+
+	// Basic stats
+	fmt.Printf("%sTotal Snippets: %d\n", emoji("📚"), report.TotalSnippets)
+	fmt.Printf("%sExecutable Snippets: %d\n", emoji("🚀"), report.ExecutableSnippets)
+	fmt.Printf("%sNon-executable Snippets: %d\n", emoji("📋"), report.TotalSnippets-report.ExecutableSnippets)
+	fmt.Printf("%sTotal Usage: %d times\n", emoji("📈"), report.TotalUsage)
+
+	if report.TotalSnippets > 0 {
+		fmt.Printf("%sAverage Usage: %.1f times per snippet\n", emoji("📊"), report.AverageUsage)
+	}
+
+	// Most used snippets
+	fmt.Printf("\n%sTop 5 Most Used Snippets:\n", emoji("🏆"))
+	fmt.Printf("───────────────────────────────────────\n")
+
+	for i, s := range report.MostUsed {
 		fmt.Printf("%d. %s — used %d times", i+1, s.Title, s.UsageCount)
 		if s.LastUsed != "" {
 			if lastUsed, err := time.Parse(time.RFC3339, s.LastUsed); err == nil {
@@ -90,80 +238,61 @@ func displayStats(snippets []snippet.Snippet, detailed bool) {
 		fmt.Println()
 	}
 
-	// Most popular languages
-	fmt.Printf("\n💻 Top Languages:\n")
+	// Highest frecency snippets
+	fmt.Printf("\n%sTrending (frequent and recent):\n", emoji("🔥"))
 	fmt.Printf("───────────────────────────────────────\n")
 
-	type languageStat struct {
-		name  string
-		count int
+	if len(report.Trending) == 0 {
+		fmt.Println("• No snippets used yet")
 	}
-
-	var languageStats []languageStat
-	for lang, count := range languageCount {
-		languageStats = append(languageStats, languageStat{lang, count})
+	for i, s := range report.Trending {
+		fmt.Printf("%d. %s — score %.2f\n", i+1, s.Title, s.Frecency)
 	}
 
-	sort.Slice(languageStats, func(i, j int) bool {
-		return languageStats[i].count > languageStats[j].count
-	})
-
-	topLangCount := 5
-	if len(languageStats) < 5 {
-		topLangCount = len(languageStats)
+	recorded := 0
+	for _, count := range report.UsageByCommand {
+		recorded += count
 	}
-
-	for i := 0; i < topLangCount; i++ {
-		lang := languageStats[i]
-		percentage := float64(lang.count) / float64(totalSnippets) * 100
-		fmt.Printf("%d. %s — %d snippets (%.1f%%)\n", i+1, lang.name, lang.count, percentage)
+	if recorded > 0 {
+		fmt.Printf("\n%sRecorded uses: %d printed, %d copied, %d executed\n", emoji("📜"),
+			report.UsageByCommand[snippet.UsagePrint], report.UsageByCommand[snippet.UsageCopy], report.UsageByCommand[snippet.UsageExec])
 	}
 
-	// Most popular tags
-	fmt.Printf("\n🏷️  Top Tags:\n")
+	// Most popular languages
+	fmt.Printf("\n%sTop Languages:\n", emoji("💻"))
 	fmt.Printf("───────────────────────────────────────\n")
 
-	type tagStat struct {
-		name  string
-		count int
-	}
-
-	var tagStats []tagStat
-	for tag, count := range tagCount {
-		tagStats = append(tagStats, tagStat{tag, count})
+	for i := 0; i < len(report.Languages) && i < 5; i++ {
+		lang := report.Languages[i]
+		percentage := float64(lang.Count) / float64(report.TotalSnippets) * 100
+		fmt.Printf("%d. %s — %d snippets (%.1f%%)\n", i+1, lang.Name, lang.Count, percentage)
 	}
 
-	sort.Slice(tagStats, func(i, j int) bool {
-		return tagStats[i].count > tagStats[j].count
-	})
+	// Most popular tags
+	fmt.Printf("\n%sTop Tags:\n", emoji("🏷️ "))
+	fmt.Printf("───────────────────────────────────────\n")
 
-	topTagCount := 5
-	if len(tagStats) < 5 {
-		topTagCount = len(tagStats)
+	for i := 0; i < len(report.Tags) && i < 5; i++ {
+		tag := report.Tags[i]
+		fmt.Printf("%d. %s — %d snippets\n", i+1, tag.Name, tag.Count)
 	}
 
-	for i := 0; i < topTagCount; i++ {
-		tag := tagStats[i]
-		fmt.Printf("%d. %s — %d snippets\n", i+1, tag.name, tag.count)
+	// Tag hierarchies, counting everything below each parent
+	if len(report.TagRollups) > 0 {
+		fmt.Printf("\n%sTop Tag Groups:\n", emoji("🌳"))
+		fmt.Printf("───────────────────────────────────────\n")
+
+		for i := 0; i < len(report.TagRollups) && i < 5; i++ {
+			group := report.TagRollups[i]
+			fmt.Printf("%d. %s/ — %d snippets\n", i+1, group.Name, group.Count)
+		}
 	}
 
 	// Recently created snippets
-	fmt.Printf("\n🆕 Recently Created:\n")
+	fmt.Printf("\n%sRecently Created:\n", emoji("🆕"))
 	fmt.Printf("───────────────────────────────────────\n")
 
-	sortedByCreated := make([]snippet.Snippet, len(snippets))
-	copy(sortedByCreated, snippets)
-	sort.Slice(sortedByCreated, func(i, j int) bool {
-		return sortedByCreated[i].CreatedAt > sortedByCreated[j].CreatedAt
-	})
-
-	recentCount := 3
-	if len(sortedByCreated) < 3 {
-		recentCount = len(sortedByCreated)
-	}
-
-	for i := 0; i < recentCount; i++ {
-		s := sortedByCreated[i]
+	for _, s := range report.RecentlyCreated {
 		if created, err := time.Parse(time.RFC3339, s.CreatedAt); err == nil {
 			fmt.Printf("• %s — created %s\n", s.Title, formatTimeAgo(created))
 		} else {
@@ -172,30 +301,13 @@ func displayStats(snippets []snippet.Snippet, detailed bool) {
 	}
 
 	// Recently used snippets
-	fmt.Printf("\n🕒 Recently Used:\n")
+	fmt.Printf("\n%sRecently Used:\n", emoji("🕒"))
 	fmt.Printf("───────────────────────────────────────\n")
 
-	var recentlyUsed []snippet.Snippet
-	for _, s := range snippets {
-		if s.LastUsed != "" {
-			recentlyUsed = append(recentlyUsed, s)
-		}
-	}
-
-	sort.Slice(recentlyUsed, func(i, j int) bool {
-		return recentlyUsed[i].LastUsed > recentlyUsed[j].LastUsed
-	})
-
-	recentUsedCount := 3
-	if len(recentlyUsed) < 3 {
-		recentUsedCount = len(recentlyUsed)
-	}
-
-	if recentUsedCount == 0 {
+	if len(report.RecentlyUsed) == 0 {
 		fmt.Println("• No snippets used yet")
 	} else {
-		for i := 0; i < recentUsedCount; i++ {
-			s := recentlyUsed[i]
+		for _, s := range report.RecentlyUsed {
 			if lastUsed, err := time.Parse(time.RFC3339, s.LastUsed); err == nil {
 				fmt.Printf("• %s — used %s\n", s.Title, formatTimeAgo(lastUsed))
 			} else {
@@ -206,37 +318,37 @@ func displayStats(snippets []snippet.Snippet, detailed bool) {
 
 	// Detailed stats if requested
 	if detailed {
-		fmt.Printf("\n📋 Detailed Statistics:\n")
+		fmt.Printf("\n%sDetailed Statistics:\n", emoji("📋"))
 		fmt.Printf("───────────────────────────────────────\n")
 
 		// Unused snippets
-		var unusedSnippets []snippet.Snippet
-		for _, s := range snippets {
-			if s.UsageCount == 0 {
-				unusedSnippets = append(unusedSnippets, s)
-			}
-		}
-
-		if len(unusedSnippets) > 0 {
-			fmt.Printf("😴 Unused Snippets (%d):\n", len(unusedSnippets))
-			for _, s := range unusedSnippets {
+		if len(report.Unused) > 0 {
+			fmt.Printf("%sUnused Snippets (%d):\n", emoji("😴"), len(report.Unused))
+			for _, s := range report.Unused {
 				fmt.Printf("   • %s (%s)\n", s.Title, s.Language)
 			}
 			fmt.Println()
 		}
 
 		// All languages breakdown
-		fmt.Printf("💻 All Languages:\n")
-		for _, lang := range languageStats {
-			fmt.Printf("   • %s: %d snippets\n", lang.name, lang.count)
+		fmt.Printf("%sAll Languages:\n", emoji("💻"))
+		for _, lang := range report.Languages {
+			fmt.Printf("   • %s: %d snippets\n", lang.Name, lang.Count)
 		}
 		fmt.Println()
 
 		// All tags breakdown
-		if len(tagStats) > 0 {
-			fmt.Printf("🏷️  All Tags:\n")
-			for _, tag := range tagStats {
-				fmt.Printf("   • %s: %d snippets\n", tag.name, tag.count)
+		if len(report.Tags) > 0 {
+			fmt.Printf("%sAll Tags:\n", emoji("🏷️ "))
+			for _, tag := range report.Tags {
+				fmt.Printf("   • %s: %d snippets\n", tag.Name, tag.Count)
+			}
+		}
+
+		if len(report.TagRollups) > 0 {
+			fmt.Printf("\n%sAll Tag Groups:\n", emoji("🌳"))
+			for _, group := range report.TagRollups {
+				fmt.Printf("   • %s/: %d snippets\n", group.Name, group.Count)
 			}
 		}
 	}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// tableColumn describes one column of 'list --table'. Flexible columns are
+// shrunk, down to minWidth, when the table is wider than the terminal.
+type tableColumn struct {
+	name     string
+	header   string
+	flexible bool
+	minWidth int
+	value    func(s snippet.Snippet, frecency float64) string
+}
+
+var tableColumns = []tableColumn{
+	{name: "id", header: "ID", value: func(s snippet.Snippet, _ float64) string { return shortID(s.ID) }},
+	{name: "title", header: "TITLE", flexible: true, minWidth: 12, value: func(s snippet.Snippet, _ float64) string { return s.Title }},
+	{name: "language", header: "LANGUAGE", value: func(s snippet.Snippet, _ float64) string { return s.Language }},
+	{name: "tags", header: "TAGS", flexible: true, minWidth: 8, value: func(s snippet.Snippet, _ float64) string { return strings.Join(s.Tags, ",") }},
+	{name: "exec", header: "EXEC", value: func(s snippet.Snippet, _ float64) string {
+		if s.Executable {
+			return "yes"
+		}
+		return "no"
+	}},
+	{name: "uses", header: "USES", value: func(s snippet.Snippet, _ float64) string { return strconv.Itoa(s.UsageCount) }},
+	{name: "last-used", header: "LAST USED", value: func(s snippet.Snippet, _ float64) string { return timeAgoOr(s.LastUsed, "never") }},
+	{name: "created", header: "CREATED", value: func(s snippet.Snippet, _ float64) string { return timeAgoOr(s.CreatedAt, "") }},
+	{name: "frecency", header: "FRECENCY", value: func(_ snippet.Snippet, f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }},
+	{name: "description", header: "DESCRIPTION", flexible: true, minWidth: 12, value: func(s snippet.Snippet, _ float64) string {
+		return strings.Join(strings.Fields(s.Description), " ")
+	}},
+}
+
+var (
+	defaultTableColumns = []string{"id", "title", "language", "tags", "exec", "uses", "last-used"}
+	wideTableColumns    = []string{"id", "title", "language", "tags", "exec", "uses", "last-used", "created", "description"}
+)
+
+const tableGap = "  "
+
+func addTableFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("table", false, "Show snippets as a compact table")
+	cmd.Flags().String("columns", "", "Comma-separated table columns: "+strings.Join(tableColumnNames(), ", "))
+	cmd.Flags().BoolP("wide", "w", false, "Table with extra columns and no truncation to the terminal width")
+}
+
+func tableColumnNames() []string {
+	names := make([]string, len(tableColumns))
+	for i, c := range tableColumns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// parseTableColumns resolves the --columns flag, falling back to the default
+// or wide column set.
+func parseTableColumns(raw string, wide bool) ([]tableColumn, error) {
+	names := defaultTableColumns
+	if wide {
+		names = wideTableColumns
+	}
+	if strings.TrimSpace(raw) != "" {
+		names = strings.Split(raw, ",")
+	}
+
+	var columns []tableColumn
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, c := range tableColumns {
+			if c.name == name {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column '%s'. Valid columns: %s", name, strings.Join(tableColumnNames(), ", "))
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return columns, nil
+}
+
+// writeTable renders snippets as aligned columns. maxWidth limits the total
+// line width; zero means no limit.
+func writeTable(w io.Writer, columns []tableColumn, snippets []snippet.Snippet, maxWidth int) {
+	var scores map[string]float64
+	for _, c := range columns {
+		if c.name == "frecency" {
+			scores = loadFrecency(snippets)
+		}
+	}
+
+	rows := make([][]string, len(snippets))
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = runewidth.StringWidth(c.header)
+	}
+	for r, s := range snippets {
+		rows[r] = make([]string, len(columns))
+		for i, c := range columns {
+			cell := strings.ReplaceAll(c.value(s, scores[s.ID]), "\n", " ")
+			rows[r][i] = cell
+			if cw := runewidth.StringWidth(cell); cw > widths[i] {
+				widths[i] = cw
+			}
+		}
+	}
+
+	if maxWidth > 0 {
+		fitColumns(columns, widths, maxWidth)
+	}
+
+	writeRow := func(cells []string) {
+		var line strings.Builder
+		for i, cell := range cells {
+			cell = runewidth.Truncate(cell, widths[i], "…")
+			if i == len(cells)-1 {
+				line.WriteString(cell)
+				break
+			}
+			line.WriteString(runewidth.FillRight(cell, widths[i]))
+			line.WriteString(tableGap)
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	writeRow(headers)
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
+
+// fitColumns shrinks the widest flexible column one cell at a time until the
+// table fits in maxWidth or every flexible column is at its minimum width.
+func fitColumns(columns []tableColumn, widths []int, maxWidth int) {
+	total := func() int {
+		sum := len(tableGap) * (len(widths) - 1)
+		for _, w := range widths {
+			sum += w
+		}
+		return sum
+	}
+
+	for total() > maxWidth {
+		widest := -1
+		for i, c := range columns {
+			if c.flexible && widths[i] > c.minWidth && (widest == -1 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			return
+		}
+		widths[widest]--
+	}
+}
+
+// terminalWidth returns the width of the terminal on stdout, or $COLUMNS, or
+// zero when neither is known.
+func terminalWidth() int {
+	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
+		if width, _, err := term.GetSize(fd); err == nil && width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+func timeAgoOr(timestamp, fallback string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return fallback
+	}
+	return formatTimeAgo(t)
+}
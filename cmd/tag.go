@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var tagCmd = &cobra.Command{
+	Use:     "tag",
+	Aliases: []string{"tags"},
+	Short:   "List, add, remove, rename and merge tags",
+	Long: `Manage the tags on your snippets.
+
+Tags are normalized: they are stored in lower case with surrounding
+whitespace trimmed and inner spaces replaced by hyphens, so "Docker" and
+" docker " are the same tag.
+
+Examples:
+  codestash tag list
+  codestash tag add 3fa9 k8s deploy
+  codestash tag remove 3fa9 old
+  codestash tag rename k8s kubernetes
+  codestash tag merge docker containers --into container`,
+}
+
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every tag with the number of snippets using it",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		counts := make(map[string]int)
+		for _, s := range snippets {
+			for _, tag := range s.Tags {
+				counts[tag]++
+			}
+		}
+		tags := sortedCounts(counts)
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, tags); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if len(tags) == 0 {
+			noticef("📭", "No tags yet. Use 'codestash tag add' to tag a snippet!")
+			return
+		}
+
+		width := 0
+		for _, t := range tags {
+			width = max(width, len(t.Name))
+		}
+		fmt.Printf("%sTags (%d):\n", emoji("🏷️ "), len(tags))
+		for _, t := range tags {
+			fmt.Printf("   %-*s  %d\n", width, t.Name, t.Count)
+		}
+	},
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add [snippet-id-or-title] [tags...]",
+	Short: "Add tags to a snippet",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateSnippetTags(args[0], args[1:], true)
+	},
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:     "remove [snippet-id-or-title] [tags...]",
+	Aliases: []string{"rm"},
+	Short:   "Remove tags from a snippet",
+	Args:    cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateSnippetTags(args[0], args[1:], false)
+	},
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename [old] [new]",
+	Short: "Rename a tag on every snippet",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		retag(args[:1], args[1], "Renamed tag %s to '%s'")
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:   "merge [tags...] --into [tag]",
+	Short: "Replace several tags with one on every snippet",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		into, _ := cmd.Flags().GetString("into")
+		retag(args, into, "Merged tag(s) %s into '%s'")
+	},
+}
+
+// updateSnippetTags adds or removes tags on the snippet matching ref.
+func updateSnippetTags(ref string, rawTags []string, add bool) {
+	var tags []string
+	for _, raw := range rawTags {
+		tags = append(tags, parseTags(raw)...)
+	}
+	if len(tags) == 0 {
+		failf("invalid_argument", "No tags given")
+		return
+	}
+
+	snippets, err := store.LoadSnippets()
+	if err != nil {
+		failf("load_failed", "Failed to load snippets: %v", err)
+		return
+	}
+	before := cloneSnippets(snippets)
+
+	target, err := findSnippet(snippets, ref)
+	if err != nil {
+		printResolveError(ref, err)
+		return
+	}
+
+	var changed []string
+	for _, tag := range tags {
+		if add && target.AddTag(tag) || !add && target.RemoveTag(tag) {
+			changed = append(changed, tag)
+		}
+	}
+	if len(changed) == 0 {
+		if add {
+			noticef("💤", "'%s' already has those tags", target.Title)
+		} else {
+			noticef("💤", "'%s' has none of those tags", target.Title)
+		}
+		return
+	}
+
+	if err := store.SaveSnippets(snippets); err != nil {
+		failf("save_failed", "Failed to save snippets: %v", err)
+		return
+	}
+
+	summary := fmt.Sprintf("Added tag(s) %s to '%s'", strings.Join(changed, ", "), target.Title)
+	if !add {
+		summary = fmt.Sprintf("Removed tag(s) %s from '%s'", strings.Join(changed, ", "), target.Title)
+	}
+	recordChange(summary, before, snippets)
+	successf("%s", summary)
+}
+
+// retag replaces each of the tags in from with into on every snippet.
+// action formats the summary from the old tags and the new one.
+func retag(from []string, into, action string) {
+	into = snippet.NormalizeTag(into)
+	if into == "" {
+		failf("usage", "The new tag cannot be empty")
+		return
+	}
+	var old []string
+	for _, raw := range from {
+		for _, tag := range parseTags(raw) {
+			if tag != into {
+				old = append(old, tag)
+			}
+		}
+	}
+	if len(old) == 0 {
+		failf("usage", "Nothing to do: the tags are already '%s'", into)
+		return
+	}
+
+	snippets, err := store.LoadSnippets()
+	if err != nil {
+		failf("load_failed", "Failed to load snippets: %v", err)
+		return
+	}
+	before := cloneSnippets(snippets)
+
+	updated := 0
+	for i := range snippets {
+		changed := false
+		for _, tag := range old {
+			if snippets[i].RenameTag(tag, into) {
+				changed = true
+			}
+		}
+		if changed {
+			updated++
+		}
+	}
+	if updated == 0 {
+		noticef("🔍", "No snippets are tagged %s", strings.Join(old, ", "))
+		return
+	}
+
+	if err := store.SaveSnippets(snippets); err != nil {
+		failf("save_failed", "Failed to save snippets: %v", err)
+		return
+	}
+
+	summary := fmt.Sprintf(action+" on %d snippet(s)", strings.Join(old, ", "), into, updated)
+	recordChange(summary, before, snippets)
+	successf("%s", summary)
+}
+
+func init() {
+	tagMergeCmd.Flags().String("into", "", "The tag to merge into (required)")
+	tagMergeCmd.MarkFlagRequired("into")
+
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+	tagCmd.AddCommand(tagRenameCmd)
+	tagCmd.AddCommand(tagMergeCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree [tag]",
+	Short: "Show hierarchical tags as a tree with snippet counts",
+	Long: `Show tags as a tree, splitting hierarchical tags such as cloud/aws/s3
+at each slash. Every node shows how many snippets are tagged with it or
+anything below it.
+
+Examples:
+  codestash tree
+  codestash tree cloud/aws --snippets`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showSnippets, _ := cmd.Flags().GetBool("snippets")
+
+		snippets, err := store.LoadSnippets()
+		if err != nil {
+			failf("load_failed", "Failed to load snippets: %v", err)
+			return
+		}
+
+		nodes := snippet.TagTree(snippets)
+		if len(args) == 1 {
+			node := findTagNode(nodes, snippet.NormalizeTag(args[0]))
+			if node == nil {
+				failf("not_found", "Tag '%s' not found", args[0])
+				return
+			}
+			nodes = []*snippet.TagNode{node}
+		}
+
+		if structuredOutput() {
+			if err := writeOutput(os.Stdout, nodes); err != nil {
+				failf("output_failed", "Failed to write output: %v", err)
+			}
+			return
+		}
+
+		if len(nodes) == 0 {
+			noticef("📭", "No tags yet. Use 'codestash tag add' to tag a snippet!")
+			return
+		}
+
+		titles := make(map[string]string, len(snippets))
+		for _, s := range snippets {
+			titles[s.ID] = s.Title
+		}
+		for _, n := range nodes {
+			fmt.Printf("%s%s (%d)\n", emoji("🏷️ "), n.Path, n.Count)
+			printTagNode(n, "", showSnippets, titles)
+		}
+
+		if len(args) == 0 {
+			untagged := 0
+			for _, s := range snippets {
+				if len(s.Tags) == 0 {
+					untagged++
+				}
+			}
+			if untagged > 0 {
+				fmt.Printf("%s(untagged) (%d)\n", emoji("📄"), untagged)
+			}
+		}
+	},
+}
+
+// printTagNode prints the snippets and children of n below it, drawing the
+// branches after prefix. Snippets tagged with n itself come first.
+func printTagNode(n *snippet.TagNode, prefix string, showSnippets bool, titles map[string]string) {
+	var ids []string
+	if showSnippets {
+		ids = n.Snippets
+	}
+	total := len(ids) + len(n.Children)
+	for i := 0; i < total; i++ {
+		branch, indent := "├── ", "│   "
+		if i == total-1 {
+			branch, indent = "└── ", "    "
+		}
+		if i < len(ids) {
+			fmt.Printf("%s%s• %s  %s\n", prefix, branch, shortID(ids[i]), titles[ids[i]])
+			continue
+		}
+		child := n.Children[i-len(ids)]
+		fmt.Printf("%s%s%s (%d)\n", prefix, branch, child.Name, child.Count)
+		printTagNode(child, prefix+indent, showSnippets, titles)
+	}
+}
+
+// findTagNode returns the node with the given path, searching below nodes.
+func findTagNode(nodes []*snippet.TagNode, path string) *snippet.TagNode {
+	for _, n := range nodes {
+		if n.Path == path {
+			return n
+		}
+		if snippet.TagWithin(path, n.Path) {
+			return findTagNode(n.Children, path)
+		}
+	}
+	return nil
+}
+
+func init() {
+	treeCmd.Flags().BoolP("snippets", "s", false, "List the snippets under each tag")
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/AngeloMihaelle/CodeStash/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last change to your snippets",
+	Long: `Undo the most recent change recorded in the journal (see 'codestash log').
+Adding, editing, deleting, importing, bulk changes, tag changes and merged
+duplicates can all be undone; uses of a snippet are kept.
+
+If a snippet was changed again after the change being undone, nothing is
+touched unless --force is given.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		force, _ := cmd.Flags().GetBool("force")
+		stepJournal(true, steps, force)
+	},
+}
+
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the last undone change",
+	Long: `Redo the change most recently reverted by 'codestash undo'. Making any new
+change discards the changes that could be redone.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		force, _ := cmd.Flags().GetBool("force")
+		stepJournal(false, steps, force)
+	},
+}
+
+// stepJournal undoes or redoes up to steps journal entries, saving the
+// snippets once every step has applied cleanly.
+func stepJournal(undo bool, steps int, force bool) {
+	if steps < 1 {
+		failf("invalid_argument", "--steps must be at least 1")
+		return
+	}
+
+	entries, err := store.LoadJournal()
+	if err != nil {
+		failf("load_failed", "Failed to load the journal: %v", err)
+		return
+	}
+	snippets, err := store.LoadSnippets()
+	if err != nil {
+		failf("load_failed", "Failed to load snippets: %v", err)
+		return
+	}
+
+	var done []string
+	for len(done) < steps {
+		i := nextJournalEntry(entries, undo)
+		if i < 0 {
+			break
+		}
+		e := &entries[i]
+		if undo {
+			snippets, err = e.Revert(snippets, force)
+		} else {
+			snippets, err = e.Reapply(snippets, force)
+		}
+		var conflict *snippet.ConflictError
+		if errors.As(err, &conflict) {
+			verb := map[bool]string{true: "undo", false: "redo"}[undo]
+			failf("conflict", "Cannot %s the change (%s): %d snippet(s) changed since", verb, e.Summary, len(conflict.Conflicts))
+			for _, c := range conflict.Conflicts {
+				fmt.Fprintf(os.Stderr, "   • %s\n", c)
+			}
+			hintf("Use --force to %s anyway", verb)
+			return
+		}
+		if err != nil {
+			failf("conflict", "%v", err)
+			return
+		}
+		e.Undone = undo
+		done = append(done, e.Summary)
+	}
+
+	if len(done) == 0 {
+		if undo {
+			noticef("💤", "Nothing to undo")
+		} else {
+			noticef("💤", "Nothing to redo")
+		}
+		return
+	}
+
+	if err := store.SaveSnippets(snippets); err != nil {
+		failf("save_failed", "Failed to save snippets: %v", err)
+		return
+	}
+	if err := store.SaveJournal(entries); err != nil {
+		failf("save_failed", "Snippets were restored but the journal could not be saved: %v", err)
+		return
+	}
+
+	for _, summary := range done {
+		if undo {
+			successf("Undid: %s", summary)
+		} else {
+			successf("Redid: %s", summary)
+		}
+	}
+}
+
+// nextJournalEntry returns the index of the entry that undo or redo would
+// apply next, or -1. Undone entries always follow the active ones.
+func nextJournalEntry(entries []snippet.JournalEntry, undo bool) int {
+	if undo {
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !entries[i].Undone {
+				return i
+			}
+		}
+		return -1
+	}
+	for i, e := range entries {
+		if e.Undone {
+			return i
+		}
+	}
+	return -1
+}
+
+func init() {
+	for _, c := range []*cobra.Command{undoCmd, redoCmd} {
+		c.Flags().IntP("steps", "n", 1, "Number of changes to step through")
+		c.Flags().BoolP("force", "f", false, "Apply even if the snippets changed since")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
-	"time"
 
 	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
 	"github.com/AngeloMihaelle/CodeStash/internal/store"
@@ -20,92 +19,94 @@ var useCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		snippets, err := store.LoadSnippets()
 		if err != nil {
-			fmt.Println("❌ Failed to load snippets:", err)
+			failf("load_failed", "Failed to load snippets: %v", err)
 			return
 		}
 
-		var targetSnippet *snippet.Snippet
-		query := args[0]
-
-		for i, s := range snippets {
-			if s.ID == query || strings.EqualFold(s.Title, query) {
-				targetSnippet = &snippets[i]
-				break
-			}
-		}
-
-		if targetSnippet == nil {
-			fmt.Printf("❌ Snippet '%s' not found\n", query)
+		targetSnippet, err := findSnippet(snippets, args[0])
+		if err != nil {
+			printResolveError(args[0], err)
 			return
 		}
 
-		targetSnippet.UsageCount++
-		targetSnippet.LastUsed = time.Now().UTC().Format(time.RFC3339)
-
-		if err := store.SaveSnippets(snippets); err != nil {
-			fmt.Println("⚠️  Failed to update usage stats:", err)
-		}
-
 		copy, _ := cmd.Flags().GetBool("copy")
 		execute, _ := cmd.Flags().GetBool("execute")
 		force, _ := cmd.Flags().GetBool("force")
 
+		usage := snippet.UsagePrint
+		if copy {
+			usage = snippet.UsageCopy
+		} else if execute {
+			usage = snippet.UsageExec
+		}
+		updateUsageStats(targetSnippet, usage)
+
+		if err := store.SaveSnippets(snippets); err != nil {
+			warnf("Failed to update usage stats: %v", err)
+		}
+
 		if copy {
 			if err := copyToClipboard(targetSnippet.Code); err != nil {
-				fmt.Println("❌ Failed to copy to clipboard:", err)
+				failf("clipboard", "Failed to copy to clipboard: %v", err)
 				return
 			}
-			fmt.Printf("📋 Copied '%s' to clipboard\n", targetSnippet.Title)
+			noticef("📋", "Copied '%s' to clipboard", targetSnippet.Title)
 		} else if execute {
 			if !targetSnippet.Executable && !force {
-				fmt.Printf("❌ Snippet '%s' is not marked as executable\n", targetSnippet.Title)
-				fmt.Println("💡 Use --force to execute anyway, or mark the snippet as executable")
+				failf("not_executable", "Snippet '%s' is not marked as executable", targetSnippet.Title)
+				hintf("Use --force to execute anyway, or mark the snippet as executable")
 				return
 			}
 
 			if !targetSnippet.Executable && force {
-				fmt.Printf("⚠️  Forcing execution of non-executable snippet '%s'\n", targetSnippet.Title)
+				warnf("Forcing execution of non-executable snippet '%s'", targetSnippet.Title)
 			}
 
 			if err := executeSnippet(targetSnippet); err != nil {
-				fmt.Println("❌ Failed to execute snippet:", err)
+				failf("exec_failed", "Failed to execute snippet: %v", err)
 				return
 			}
+		} else if rawOutput(cmd) {
+			writeRawCode(targetSnippet.Code)
 		} else {
-			fmt.Printf("📄 %s\n", targetSnippet.Title)
-			fmt.Printf("📝 %s\n", targetSnippet.Description)
+			fmt.Printf("%s%s\n", emoji("📄"), targetSnippet.Title)
+			fmt.Printf("%s%s\n", emoji("📝"), targetSnippet.Description)
 			if targetSnippet.Executable {
-				fmt.Println("🚀 This snippet is executable")
+				fmt.Println(emoji("🚀") + "This snippet is executable")
 			}
 			fmt.Println("─────────────────────────────────────")
-			fmt.Println(targetSnippet.Code)
+			fmt.Println(highlightCode(targetSnippet.Code, targetSnippet.Language))
 			fmt.Println("─────────────────────────────────────")
 		}
 	},
 }
 
 func copyToClipboard(text string) error {
-	var cmd *exec.Cmd
+	cmd, err := clipboardCommand()
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
 
+// clipboardCommand returns the command that copies its standard input to
+// the clipboard on this platform.
+func clipboardCommand() (*exec.Cmd, error) {
 	switch runtime.GOOS {
 	case "darwin":
-		cmd = exec.Command("pbcopy")
+		return exec.Command("pbcopy"), nil
 	case "linux":
 		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
+			return exec.Command("xclip", "-selection", "clipboard"), nil
 		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else {
-			return fmt.Errorf("clipboard access requires xclip or xsel on Linux")
+			return exec.Command("xsel", "--clipboard", "--input"), nil
 		}
+		return nil, fmt.Errorf("clipboard access requires xclip or xsel on Linux")
 	case "windows":
-		cmd = exec.Command("clip")
-	default:
-		return fmt.Errorf("unsupported platform for clipboard operations: %s", runtime.GOOS)
+		return exec.Command("clip"), nil
 	}
-
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
+	return nil, fmt.Errorf("unsupported platform for clipboard operations: %s", runtime.GOOS)
 }
 
 func executeSnippet(s *snippet.Snippet) error {
@@ -122,8 +123,8 @@ func executeSnippet(s *snippet.Snippet) error {
 		return fmt.Errorf("snippet '%s' is not marked as executable or shell-compatible", s.Title)
 	}
 
-	fmt.Printf("🚀 Executing '%s'...\n", s.Title)
-	fmt.Println("─────────────────────────────────────")
+	noticef("🚀", "Executing '%s'...", s.Title)
+	fmt.Fprintln(os.Stderr, "─────────────────────────────────────")
 
 	var cmd *exec.Cmd
 
@@ -161,17 +162,9 @@ func executeSnippet(s *snippet.Snippet) error {
 
 	default:
 		shell := "/bin/sh"
-		if strings.EqualFold(s.Language, "bash") {
-			if _, err := exec.LookPath("bash"); err == nil {
-				shell = "bash"
-			}
-		} else if strings.EqualFold(s.Language, "zsh") {
-			if _, err := exec.LookPath("zsh"); err == nil {
-				shell = "zsh"
-			}
-		} else if strings.EqualFold(s.Language, "fish") {
-			if _, err := exec.LookPath("fish"); err == nil {
-				shell = "fish"
+		if name := unixShell(s.Language); name != "" {
+			if _, err := exec.LookPath(name); err == nil {
+				shell = name
 			}
 		}
 		cmd = exec.Command(shell, "-c", s.Code)
@@ -183,6 +176,17 @@ func executeSnippet(s *snippet.Snippet) error {
 	return cmd.Run()
 }
 
+// unixShell returns the shell that runs snippets in language on Unix, or ""
+// for languages that run with /bin/sh.
+func unixShell(language string) string {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		if strings.EqualFold(language, shell) {
+			return shell
+		}
+	}
+	return ""
+}
+
 func writeTempScript(code, extension string) (string, error) {
 	tmpFile, err := os.CreateTemp("", "*"+extension)
 	if err != nil {
@@ -204,6 +208,7 @@ func init() {
 	useCmd.Flags().BoolP("copy", "c", false, "Copy snippet to clipboard")
 	useCmd.Flags().BoolP("execute", "x", false, "Execute snippet")
 	useCmd.Flags().BoolP("force", "f", false, "Force execution even if not marked as executable")
+	useCmd.Flags().BoolP("raw", "r", false, "Print only the code (default when stdout is not a terminal)")
 }
 
 func parseCommand(command string) (string, []string) {
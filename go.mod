@@ -2,9 +2,19 @@ module github.com/AngeloMihaelle/CodeStash
 
 go 1.24.4
 
-require github.com/spf13/cobra v1.9.1
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/alecthomas/chroma/v2 v2.24.1
+	github.com/mattn/go-runewidth v0.0.16
+	github.com/spf13/cobra v1.9.1
+	golang.org/x/term v0.40.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
+	github.com/dlclark/regexp2 v1.12.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
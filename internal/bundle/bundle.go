@@ -0,0 +1,89 @@
+// Package bundle reads and writes portable snippet bundles and merges
+// imported snippets into an existing stash.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// Format identifies a codestash bundle file.
+const Format = "codestash-bundle"
+
+// Version is the bundle version written by Write.
+const Version = 1
+
+// Extension is the suggested file extension for bundles.
+const Extension = ".cstash"
+
+// Bundle is a self-describing set of snippets that can be moved between
+// machines.
+type Bundle struct {
+	Format     string            `json:"format"`
+	Version    int               `json:"version"`
+	ExportedAt string            `json:"exported_at"`
+	Snippets   []snippet.Snippet `json:"snippets"`
+}
+
+// New wraps snippets in a bundle. Unless keepUsage is set, personal usage
+// statistics are cleared so they do not travel with the snippets.
+func New(snippets []snippet.Snippet, keepUsage bool) Bundle {
+	out := make([]snippet.Snippet, len(snippets))
+	for i, s := range snippets {
+		if !keepUsage {
+			s.UsageCount = 0
+			s.LastUsed = ""
+		}
+		if s.Tags == nil {
+			s.Tags = []string{}
+		}
+		out[i] = s
+	}
+	return Bundle{
+		Format:     Format,
+		Version:    Version,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Snippets:   out,
+	}
+}
+
+// Write encodes b as indented JSON.
+func Write(w io.Writer, b Bundle) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Read decodes a bundle. A plain JSON array of snippets, as found in
+// snippets.json, is accepted as well.
+func Read(r io.Reader) ([]snippet.Snippet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		var snippets []snippet.Snippet
+		if err := json.Unmarshal(data, &snippets); err != nil {
+			return nil, fmt.Errorf("invalid snippets file: %v", err)
+		}
+		return snippets, nil
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %v", err)
+	}
+	if b.Format != Format {
+		return nil, fmt.Errorf("not a codestash bundle (format %q)", b.Format)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("bundle version %d is newer than this codestash supports (%d)", b.Version, Version)
+	}
+	return b.Snippets, nil
+}
@@ -0,0 +1,247 @@
+package bundle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// Policy decides what happens when an imported snippet conflicts with one
+// already in the stash.
+type Policy string
+
+// Conflict policies.
+const (
+	// Skip keeps the existing snippet and drops the imported one.
+	Skip Policy = "skip"
+	// Overwrite replaces the existing snippet's content, keeping its ID and
+	// usage history.
+	Overwrite Policy = "overwrite"
+	// Rename adds the imported snippet under a new ID and title.
+	Rename Policy = "rename"
+	// MergeFields keeps the existing code, combines tags and fills in missing
+	// fields from the imported snippet.
+	MergeFields Policy = "merge"
+)
+
+// Policies lists the accepted conflict policies.
+var Policies = []Policy{Skip, Overwrite, Rename, MergeFields}
+
+// ParsePolicy validates a --on-conflict value.
+func ParsePolicy(s string) (Policy, error) {
+	for _, p := range Policies {
+		if strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+	names := make([]string, len(Policies))
+	for i, p := range Policies {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown conflict policy '%s', expected %s", s, strings.Join(names, ", "))
+}
+
+// Actions recorded in a Report.
+const (
+	ActionAdded   = "added"
+	ActionUpdated = "updated"
+	ActionSkipped = "skipped"
+)
+
+// Change describes what happened to one imported snippet.
+type Change struct {
+	Action string `json:"action" yaml:"action"`
+	ID     string `json:"id" yaml:"id"`
+	Title  string `json:"title" yaml:"title"`
+	// OriginalID is set when the snippet was given a new ID.
+	OriginalID string `json:"original_id,omitempty" yaml:"original_id,omitempty"`
+	Reason     string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Report summarises an import.
+type Report struct {
+	Added   int      `json:"added" yaml:"added"`
+	Updated int      `json:"updated" yaml:"updated"`
+	Skipped int      `json:"skipped" yaml:"skipped"`
+	Changes []Change `json:"changes" yaml:"changes"`
+}
+
+func (r *Report) record(c Change) {
+	switch c.Action {
+	case ActionAdded:
+		r.Added++
+	case ActionUpdated:
+		r.Updated++
+	case ActionSkipped:
+		r.Skipped++
+	}
+	r.Changes = append(r.Changes, c)
+}
+
+// Merge imports incoming snippets into existing and returns the combined
+// stash. An imported snippet conflicts with an existing one that has the same
+// ID, the same code or the same title, in that order; identical snippets are
+// always skipped. Imported IDs that are missing or already taken by an
+// unrelated snippet are replaced with fresh ones.
+func Merge(existing, incoming []snippet.Snippet, policy Policy) ([]snippet.Snippet, Report) {
+	result := append([]snippet.Snippet(nil), existing...)
+	report := Report{Changes: []Change{}}
+
+	ids := map[string]bool{}
+	titles := map[string]bool{}
+	// IDs resolve case-insensitively, so they must be unique that way too
+	for _, s := range result {
+		ids[strings.ToLower(s.ID)] = true
+		titles[strings.ToLower(s.Title)] = true
+	}
+	codes := newCodeIndex(result)
+
+	add := func(s snippet.Snippet, reason string) {
+		original := s.ID
+		if s.ID == "" || ids[strings.ToLower(s.ID)] {
+			s.ID = snippet.UniqueID(ids)
+		}
+		if s.ID == original {
+			original = ""
+		}
+		if s.CreatedAt == "" {
+			s.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+		}
+		ids[strings.ToLower(s.ID)] = true
+		titles[strings.ToLower(s.Title)] = true
+		result = append(result, s)
+		codes.add(s.Code)
+		report.record(Change{Action: ActionAdded, ID: s.ID, Title: s.Title, OriginalID: original, Reason: reason})
+	}
+
+	for _, in := range incoming {
+		if strings.TrimSpace(in.Code) == "" || strings.TrimSpace(in.Title) == "" {
+			report.record(Change{Action: ActionSkipped, ID: in.ID, Title: in.Title, Reason: "missing title or code"})
+			continue
+		}
+		in.Tags = snippet.NormalizeTags(in.Tags)
+
+		idx, reason := findConflict(result, codes, in)
+		if idx < 0 {
+			add(in, "")
+			continue
+		}
+		current := &result[idx]
+		if snippet.SameContent(*current, in) {
+			report.record(Change{Action: ActionSkipped, ID: current.ID, Title: current.Title, Reason: "unchanged"})
+			continue
+		}
+
+		switch policy {
+		case Overwrite:
+			current.Title = in.Title
+			current.Code = in.Code
+			codes.set(idx, in.Code)
+			current.Tags = in.Tags
+			current.Executable = in.Executable
+			current.Language = in.Language
+			current.Description = in.Description
+			report.record(Change{Action: ActionUpdated, ID: current.ID, Title: current.Title, Reason: reason})
+		case MergeFields:
+			current.Merge(in)
+			if current.Description == "" {
+				current.Description = in.Description
+			}
+			if current.Language == "" {
+				current.Language = in.Language
+			}
+			report.record(Change{Action: ActionUpdated, ID: current.ID, Title: current.Title, Reason: reason})
+		case Rename:
+			in.ID = ""
+			in.Title = uniqueTitle(in.Title, titles)
+			add(in, reason)
+		default:
+			report.record(Change{Action: ActionSkipped, ID: current.ID, Title: current.Title, Reason: reason})
+		}
+	}
+
+	return result, report
+}
+
+// findConflict returns the index of the snippet in stash that in collides
+// with and why, or -1. A shared ID only counts when the title or code match
+// too; otherwise the IDs collided by chance and the imported snippet is added
+// under a new ID.
+func findConflict(stash []snippet.Snippet, codes *codeIndex, in snippet.Snippet) (int, string) {
+	hash := snippet.CodeHash(in.Code)
+	if in.ID != "" {
+		for i, s := range stash {
+			if strings.EqualFold(s.ID, in.ID) && (sameTitle(s, in) || codes.hashes[i] == hash) {
+				return i, "same ID"
+			}
+		}
+	}
+	if i, ok := codes.first[hash]; ok {
+		return i, "same code"
+	}
+	for i, s := range stash {
+		if sameTitle(s, in) {
+			return i, "same title"
+		}
+	}
+	return -1, ""
+}
+
+// codeIndex finds snippets in the stash by the hash of their code, so each
+// snippet is hashed once per import rather than once per imported snippet.
+type codeIndex struct {
+	// hashes[i] is the code hash of stash[i]
+	hashes []string
+	// first maps a hash to the first snippet with that code
+	first map[string]int
+}
+
+func newCodeIndex(stash []snippet.Snippet) *codeIndex {
+	x := &codeIndex{first: map[string]int{}}
+	for _, s := range stash {
+		x.add(s.Code)
+	}
+	return x
+}
+
+// add indexes the code of a snippet appended to the stash.
+func (x *codeIndex) add(code string) {
+	hash := snippet.CodeHash(code)
+	if _, ok := x.first[hash]; !ok {
+		x.first[hash] = len(x.hashes)
+	}
+	x.hashes = append(x.hashes, hash)
+}
+
+// set reindexes stash[i] after its code changed.
+func (x *codeIndex) set(i int, code string) {
+	old, hash := x.hashes[i], snippet.CodeHash(code)
+	x.hashes[i] = hash
+	if x.first[old] == i {
+		delete(x.first, old)
+		for j, h := range x.hashes {
+			if h == old {
+				x.first[old] = j
+				break
+			}
+		}
+	}
+	if j, ok := x.first[hash]; !ok || i < j {
+		x.first[hash] = i
+	}
+}
+
+func sameTitle(a, b snippet.Snippet) bool {
+	return strings.EqualFold(strings.TrimSpace(a.Title), strings.TrimSpace(b.Title))
+}
+
+// uniqueTitle appends " (imported)", then a number, until title is unused.
+func uniqueTitle(title string, titles map[string]bool) string {
+	candidate := title + " (imported)"
+	for n := 2; titles[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s (imported %d)", title, n)
+	}
+	return candidate
+}
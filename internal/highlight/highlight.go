@@ -0,0 +1,172 @@
+package highlight
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// Palettes supported by Code.
+const (
+	Palette16        = "16"
+	Palette256       = "256"
+	PaletteTruecolor = "truecolor"
+)
+
+// DefaultTheme is used when no theme is configured.
+const DefaultTheme = "monokai"
+
+var paletteFormatters = map[string]string{
+	Palette16:        "terminal16",
+	Palette256:       "terminal256",
+	PaletteTruecolor: "terminal16m",
+}
+
+// languageAliases maps language names used in snippets to chroma lexer names
+// where they differ.
+var languageAliases = map[string]string{
+	"shell":  "bash",
+	"sh":     "bash",
+	"ps1":    "powershell",
+	"cmd":    "batchfile",
+	"bat":    "batchfile",
+	"batch":  "batchfile",
+	"js":     "javascript",
+	"ts":     "typescript",
+	"py":     "python",
+	"golang": "go",
+	"yml":    "yaml",
+}
+
+// Options controls how code is highlighted.
+type Options struct {
+	Palette string
+	Theme   string
+}
+
+// Code returns code with ANSI colour escapes for the given language. Code in
+// a language that cannot be recognised is returned unchanged.
+func Code(code, language string, opts Options) (string, error) {
+	lexer := lexerFor(code, language)
+	if lexer == nil {
+		return code, nil
+	}
+
+	formatterName, ok := paletteFormatters[opts.Palette]
+	if !ok {
+		return "", fmt.Errorf("unknown palette '%s', expected 16, 256 or truecolor", opts.Palette)
+	}
+
+	style, err := styleFor(opts.Theme)
+	if err != nil {
+		return "", err
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return code, nil
+	}
+
+	var out strings.Builder
+	if err := formatters.Get(formatterName).Format(&out, style, iterator); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// HTML returns code as a <pre> block with inline styles, so the result needs
+// no external stylesheet. Code in a language that cannot be recognised is
+// escaped and wrapped in the theme's colours.
+func HTML(code, language, theme string) (string, error) {
+	style, err := styleFor(theme)
+	if err != nil {
+		return "", err
+	}
+
+	lexer := lexerFor(code, language)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	formatter := html.New(html.WithClasses(false), html.TabWidth(4))
+	if err := formatter.Format(&out, style, iterator); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func styleFor(theme string) (*chroma.Style, error) {
+	if theme == "" {
+		theme = DefaultTheme
+	}
+	style, ok := styles.Registry[strings.ToLower(theme)]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme '%s'. See 'codestash themes' for the list", theme)
+	}
+	return style, nil
+}
+
+// LexerName returns the name of the language that code would be highlighted
+// as, or "" when it cannot be recognised.
+func LexerName(code, language string) string {
+	lexer := lexerFor(code, language)
+	if lexer == nil {
+		return ""
+	}
+	return strings.ToLower(lexer.Config().Name)
+}
+
+// KnownLanguage reports whether language names a language that can be
+// highlighted, either directly or through one of the usual aliases.
+func KnownLanguage(language string) bool {
+	name := strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	return name != "" && lexers.Get(name) != nil
+}
+
+func lexerFor(code, language string) chroma.Lexer {
+	name := strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	if name != "" {
+		if lexer := lexers.Get(name); lexer != nil {
+			return lexer
+		}
+	}
+	return lexers.Analyse(code)
+}
+
+// Themes returns the names of the available themes, sorted.
+func Themes() []string {
+	names := styles.Names()
+	sort.Strings(names)
+	return names
+}
+
+// DetectPalette guesses the richest palette the terminal supports from
+// $COLORTERM and $TERM.
+func DetectPalette() string {
+	colorterm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorterm == "truecolor" || colorterm == "24bit" {
+		return PaletteTruecolor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Palette256
+	}
+	return Palette16
+}
@@ -0,0 +1,240 @@
+// Package history reads shell history files so recent commands can be saved
+// as snippets.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported shells.
+const (
+	Bash = "bash"
+	Zsh  = "zsh"
+	Fish = "fish"
+)
+
+// Shells lists the supported shells.
+var Shells = []string{Bash, Zsh, Fish}
+
+// Entry is one command from a history file. Time is zero when the history
+// does not record it.
+type Entry struct {
+	Command string
+	Time    time.Time
+}
+
+// DetectShell returns the shell named by $SHELL, or bash when it is not one
+// of the supported shells.
+func DetectShell() string {
+	name := filepath.Base(os.Getenv("SHELL"))
+	for _, s := range Shells {
+		if name == s {
+			return s
+		}
+	}
+	return Bash
+}
+
+// Path returns the history file for shell, honouring $HISTFILE for bash and
+// zsh and $XDG_DATA_HOME for fish.
+func Path(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	switch shell {
+	case Bash, Zsh:
+		if file := os.Getenv("HISTFILE"); file != "" && DetectShell() == shell {
+			return file, nil
+		}
+		if shell == Bash {
+			return filepath.Join(home, ".bash_history"), nil
+		}
+		return filepath.Join(home, ".zsh_history"), nil
+	case Fish:
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, "fish", "fish_history"), nil
+	}
+	return "", fmt.Errorf("unsupported shell '%s', expected %s", shell, strings.Join(Shells, ", "))
+}
+
+// Load reads the history of shell from its usual file, oldest entry first.
+func Load(shell string) ([]Entry, error) {
+	path, err := Path(shell)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(shell, data)
+}
+
+// Parse reads history in the file format of shell, oldest entry first.
+func Parse(shell string, data []byte) ([]Entry, error) {
+	switch shell {
+	case Bash:
+		return parseBash(data), nil
+	case Zsh:
+		return parseZsh(data), nil
+	case Fish:
+		return parseFish(data), nil
+	}
+	return nil, fmt.Errorf("unsupported shell '%s', expected %s", shell, strings.Join(Shells, ", "))
+}
+
+// Recent returns up to n of the most recent entries, newest first, skipping
+// repeats of the same command and commands for which skip returns true.
+func Recent(entries []Entry, n int, skip func(string) bool) []Entry {
+	var recent []Entry
+	seen := map[string]bool{}
+	for i := len(entries) - 1; i >= 0 && len(recent) < n; i-- {
+		cmd := strings.TrimSpace(entries[i].Command)
+		if cmd == "" || seen[cmd] || (skip != nil && skip(cmd)) {
+			continue
+		}
+		seen[cmd] = true
+		recent = append(recent, entries[i])
+	}
+	return recent
+}
+
+// parseBash reads ~/.bash_history, where "#<unix time>" lines written with
+// HISTTIMEFORMAT set give the time of the following command.
+func parseBash(data []byte) []Entry {
+	var entries []Entry
+	var when time.Time
+	scanner := newScanner(data)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if ts, ok := strings.CutPrefix(line, "#"); ok {
+			if secs, err := strconv.ParseInt(ts, 10, 64); err == nil {
+				when = time.Unix(secs, 0)
+				continue
+			}
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entries = append(entries, Entry{Command: line, Time: when})
+		when = time.Time{}
+	}
+	return entries
+}
+
+// parseZsh reads ~/.zsh_history in plain or extended (": time:duration;cmd")
+// format. Multi-line commands continue with a trailing backslash.
+func parseZsh(data []byte) []Entry {
+	var entries []Entry
+	var current *Entry
+	scanner := newScanner(unmetafy(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if current != nil {
+			current.Command += "\n" + strings.TrimSuffix(line, "\\")
+			if !strings.HasSuffix(line, "\\") {
+				entries = append(entries, *current)
+				current = nil
+			}
+			continue
+		}
+
+		entry := Entry{Command: line}
+		if rest, ok := strings.CutPrefix(line, ": "); ok {
+			if meta, cmd, ok := strings.Cut(rest, ";"); ok {
+				stamp, _, _ := strings.Cut(meta, ":")
+				if secs, err := strconv.ParseInt(strings.TrimSpace(stamp), 10, 64); err == nil {
+					entry = Entry{Command: cmd, Time: time.Unix(secs, 0)}
+				}
+			}
+		}
+
+		if strings.HasSuffix(entry.Command, "\\") {
+			entry.Command = strings.TrimSuffix(entry.Command, "\\")
+			current = &entry
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if current != nil {
+		entries = append(entries, *current)
+	}
+	return entries
+}
+
+// unmetafy decodes zsh's history encoding, where bytes with the high bit set
+// are written as 0x83 followed by the byte XOR 32.
+func unmetafy(data []byte) []byte {
+	if !bytes.Contains(data, []byte{0x83}) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0x83 && i+1 < len(data) {
+			i++
+			out = append(out, data[i]^32)
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return out
+}
+
+// parseFish reads fish_history, a YAML-like list of "- cmd: ..." entries
+// with a "when:" timestamp. Fish escapes newlines as \n and backslashes as
+// \\ inside cmd.
+func parseFish(data []byte) []Entry {
+	var entries []Entry
+	scanner := newScanner(data)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if cmd, ok := strings.CutPrefix(line, "- cmd: "); ok {
+			entries = append(entries, Entry{Command: unescapeFish(cmd)})
+			continue
+		}
+		if when, ok := strings.CutPrefix(strings.TrimSpace(line), "when: "); ok && len(entries) > 0 {
+			if secs, err := strconv.ParseInt(when, 10, 64); err == nil {
+				entries[len(entries)-1].Time = time.Unix(secs, 0)
+			}
+		}
+	}
+	return entries
+}
+
+func unescapeFish(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				out.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				out.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+func newScanner(data []byte) *bufio.Scanner {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return scanner
+}
@@ -0,0 +1,96 @@
+package interop
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+type naviCheat struct {
+	title   string
+	command []string
+	tags    []string
+}
+
+// ReadNavi parses a navi .cheat file. "% a, b" lines set the tags for the
+// cheats that follow, "# text" starts a cheat with text as its title and the
+// next lines up to a blank line are its command. "$ name: command" lines,
+// which tell navi how to suggest values, are noted in the description of the
+// cheats that use the variable.
+func ReadNavi(r io.Reader) ([]snippet.Snippet, error) {
+	var (
+		cheats  []naviCheat
+		current naviCheat
+		tags    []string
+	)
+	sources := map[string]string{}
+
+	flush := func() {
+		if len(current.command) > 0 {
+			current.tags = append([]string{}, tags...)
+			cheats = append(cheats, current)
+		}
+		current = naviCheat{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "%"):
+			flush()
+			tags = nil
+			for _, t := range strings.Split(strings.TrimPrefix(trimmed, "%"), ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					tags = append(tags, t)
+				}
+			}
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			current.title = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+		case strings.HasPrefix(trimmed, "$"):
+			flush()
+			name, source, ok := strings.Cut(strings.TrimPrefix(trimmed, "$"), ":")
+			if ok {
+				// Drop navi's "--- --column 1" style options
+				source, _, _ = strings.Cut(source, " --- ")
+				sources[strings.TrimSpace(name)] = strings.TrimSpace(source)
+			}
+		case strings.HasPrefix(trimmed, ";"), strings.HasPrefix(trimmed, "@"):
+			// Comments and "@ extends" lines have no snippet content
+		case trimmed == "":
+			if len(current.command) > 0 {
+				flush()
+			}
+		default:
+			current.command = append(current.command, line)
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	snippets := make([]snippet.Snippet, 0, len(cheats))
+	for _, c := range cheats {
+		code := convertAngleVariables(strings.Join(c.command, "\n"))
+		var values []string
+		for _, ref := range snippet.Placeholders(code) {
+			if source, ok := sources[ref.Name]; ok {
+				values = append(values, fmt.Sprintf("%s from `%s`", ref.Name, source))
+			}
+		}
+		description := ""
+		if len(values) > 0 {
+			description = "Values: " + strings.Join(values, "; ")
+		}
+		snippets = append(snippets, newShellSnippet(c.title, code, description, c.tags))
+	}
+	return snippets, nil
+}
@@ -0,0 +1,42 @@
+package interop
+
+import (
+	"io"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+	"github.com/BurntSushi/toml"
+)
+
+// petFile is pet's snippet.toml.
+type petFile struct {
+	Snippets []struct {
+		Description string   `toml:"description"`
+		Command     string   `toml:"command"`
+		Tag         []string `toml:"tag"`
+		Output      string   `toml:"output"`
+	} `toml:"snippets"`
+}
+
+// ReadPet parses a pet snippet.toml. The description becomes the title and
+// <name=default> variables become placeholders.
+func ReadPet(r io.Reader) ([]snippet.Snippet, error) {
+	var file petFile
+	if _, err := toml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	var snippets []snippet.Snippet
+	for _, p := range file.Snippets {
+		command := strings.TrimSpace(p.Command)
+		if command == "" {
+			continue
+		}
+		description := ""
+		if output := strings.TrimSpace(p.Output); output != "" {
+			description = "Output: " + output
+		}
+		snippets = append(snippets, newShellSnippet(p.Description, convertAngleVariables(command), description, p.Tag))
+	}
+	return snippets, nil
+}
@@ -0,0 +1,50 @@
+package interop
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// angleVariable matches the <name> and <name=default> variables used by pet
+// and navi. The name must follow the < directly, so redirections such as
+// "cat < file" are left alone.
+var angleVariable = regexp.MustCompile(`<([A-Za-z_][A-Za-z0-9_-]*)(?:=([^<>]*))?>`)
+
+// convertAngleVariables turns <name> and <name=default> into placeholders.
+// pet's choice syntax <name=|_a_||_b_|> uses the first choice as default.
+func convertAngleVariables(command string) string {
+	return angleVariable.ReplaceAllStringFunc(command, func(m string) string {
+		parts := angleVariable.FindStringSubmatch(m)
+		def := parts[2]
+		if strings.HasPrefix(def, "|_") {
+			def = strings.TrimPrefix(def, "|_")
+			if end := strings.Index(def, "_|"); end >= 0 {
+				def = def[:end]
+			}
+		}
+		return snippet.Placeholder(parts[1], def)
+	})
+}
+
+// newShellSnippet builds a bash snippet from an imported command. It is
+// executable unless it has placeholders, which exec would run as written.
+func newShellSnippet(title, command, description string, tags []string) snippet.Snippet {
+	if tags == nil {
+		tags = []string{}
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = firstLine(command)
+	}
+	return *snippet.NewSnippet(title, command, strings.TrimSpace(description), "bash", tags, len(snippet.Placeholders(command)) == 0)
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package interop
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+var (
+	// tldrPlaceholder matches tldr's {{value}} markers, which may contain
+	// spaces and punctuation that placeholder names cannot.
+	tldrPlaceholder = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	// tldrMnemonic matches the [c]reate style hints in newer pages.
+	tldrMnemonic = regexp.MustCompile(`\[(\w)\]`)
+)
+
+// ReadTldr parses a tldr page. Every example becomes a snippet titled
+// "<command>: <example description>", tagged with the command and "tldr",
+// with the page summary as its description. name is the page's file name,
+// used when the page has no "# command" heading.
+func ReadTldr(r io.Reader, name string) ([]snippet.Snippet, error) {
+	command := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	var (
+		summary     string
+		description string
+		snippets    []snippet.Snippet
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "# "):
+			command = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		case strings.HasPrefix(line, ">"):
+			text := strings.TrimSpace(strings.TrimPrefix(line, ">"))
+			if summary == "" && !strings.HasPrefix(text, "More information:") {
+				summary = text
+			}
+		case strings.HasPrefix(line, "- "):
+			description = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, "- ")), ":")
+			description = tldrMnemonic.ReplaceAllString(description, "$1")
+			if description != "" {
+				description = strings.ToUpper(description[:1]) + description[1:]
+			}
+		case strings.HasPrefix(line, "`") && strings.HasSuffix(line, "`") && len(line) > 1:
+			code := convertTldrPlaceholders(strings.Trim(line, "`"))
+			title := command
+			if description != "" {
+				title = command + ": " + description
+			}
+			snippets = append(snippets, newShellSnippet(title, code, summary, []string{command, "tldr"}))
+			description = ""
+		}
+	}
+	return snippets, scanner.Err()
+}
+
+// convertTldrPlaceholders turns {{path/to/file}} into a placeholder, using
+// the text as both name and default so nothing is lost.
+func convertTldrPlaceholders(code string) string {
+	return tldrPlaceholder.ReplaceAllStringFunc(code, func(m string) string {
+		value := tldrPlaceholder.FindStringSubmatch(m)[1]
+		name := snippet.PlaceholderName(value)
+		if name == "" {
+			return value
+		}
+		if name == value {
+			return snippet.Placeholder(name, "")
+		}
+		return snippet.Placeholder(name, value)
+	})
+}
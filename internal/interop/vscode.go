@@ -0,0 +1,360 @@
+// Package interop converts snippets to and from the formats of other tools.
+package interop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// vscodeSnippet is one entry of a VS Code snippets file. Prefix and Body may
+// be a string or an array of strings.
+type vscodeSnippet struct {
+	Prefix      json.RawMessage `json:"prefix"`
+	Body        json.RawMessage `json:"body"`
+	Description string          `json:"description,omitempty"`
+	Scope       string          `json:"scope,omitempty"`
+}
+
+// vscodeLanguages maps VS Code language identifiers to the names codestash
+// uses where they differ.
+var vscodeLanguages = map[string]string{
+	"shellscript":     "bash",
+	"bat":             "batch",
+	"javascriptreact": "javascript",
+	"typescriptreact": "typescript",
+}
+
+// ReadVSCode parses a VS Code snippets file (*.code-snippets, or a
+// per-language file such as go.json). Comments and trailing commas are
+// allowed, as in VS Code. The prefix becomes the title and tab stops become
+// placeholders; for per-language files name gives the language when an entry
+// has no scope.
+func ReadVSCode(r io.Reader, name string) ([]snippet.Snippet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(stripJSONC(data)))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("not a VS Code snippets file: expected a JSON object")
+	}
+
+	fileLanguage := ""
+	if ext := filepath.Ext(name); ext == ".json" {
+		fileLanguage = vscodeLanguage(strings.TrimSuffix(filepath.Base(name), ext))
+	}
+
+	var snippets []snippet.Snippet
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		var entry vscodeSnippet
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("snippet %q: %v", key, err)
+		}
+
+		body := strings.Join(stringOrList(entry.Body), "\n")
+		if strings.TrimSpace(body) == "" {
+			continue
+		}
+
+		title := key
+		if prefixes := stringOrList(entry.Prefix); len(prefixes) > 0 && prefixes[0] != "" {
+			title = prefixes[0]
+		}
+		description := entry.Description
+		if description == "" && key != title {
+			description = key
+		}
+
+		language := fileLanguage
+		if entry.Scope != "" {
+			language = vscodeLanguage(strings.TrimSpace(strings.Split(entry.Scope, ",")[0]))
+		}
+
+		snippets = append(snippets, *snippet.NewSnippet(title, convertTabStops(body), description, language, []string{}, false))
+	}
+	return snippets, nil
+}
+
+// WriteVSCode writes snippets as a VS Code *.code-snippets file, keyed by
+// title. Placeholders become numbered tab stops.
+func WriteVSCode(w io.Writer, snippets []snippet.Snippet) error {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	used := map[string]bool{}
+	for i, s := range snippets {
+		key := s.Title
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s (%d)", s.Title, n)
+		}
+		used[key] = true
+
+		entry := struct {
+			Prefix      string   `json:"prefix"`
+			Body        []string `json:"body"`
+			Description string   `json:"description,omitempty"`
+			Scope       string   `json:"scope,omitempty"`
+		}{
+			Prefix:      s.Title,
+			Body:        strings.Split(strings.TrimRight(toTabStops(s.Code), "\n"), "\n"),
+			Description: s.Description,
+			Scope:       vscodeScope(s.Language),
+		}
+
+		keyJSON, _ := json.Marshal(key)
+		entryJSON, err := json.MarshalIndent(entry, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "  %s: %s", keyJSON, entryJSON)
+		if i < len(snippets)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func vscodeLanguage(id string) string {
+	id = strings.ToLower(id)
+	if lang, ok := vscodeLanguages[id]; ok {
+		return lang
+	}
+	return id
+}
+
+func vscodeScope(language string) string {
+	language = strings.ToLower(language)
+	switch language {
+	case "bash", "sh", "shell", "zsh":
+		return "shellscript"
+	case "batch", "cmd":
+		return "bat"
+	}
+	return language
+}
+
+func stringOrList(raw json.RawMessage) []string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return []string{s}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	return nil
+}
+
+// convertTabStops rewrites VS Code snippet syntax into placeholders: $1 and
+// ${1} become {{1}}, ${1:default} becomes {{1:default}}, and ${1|a,b|}
+// becomes {{1:a}}. $0 is dropped and variables such as $TM_FILENAME are
+// kept as written, so shell variables survive.
+func convertTabStops(body string) string {
+	out, _ := parseTabStops(body, 0, false)
+	return out
+}
+
+// parseTabStops converts body from i until the end, or until an unescaped }
+// when nested is set. It returns the text and the index after it.
+func parseTabStops(body string, i int, nested bool) (string, int) {
+	var out strings.Builder
+	for i < len(body) {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body) && strings.ContainsRune(`$}\`, rune(body[i+1])):
+			out.WriteByte(body[i+1])
+			i += 2
+		case c == '}' && nested:
+			return out.String(), i
+		case c == '$' && i+1 < len(body) && isDigit(body[i+1]):
+			j := i + 1
+			for j < len(body) && isDigit(body[j]) {
+				j++
+			}
+			if n := body[i+1 : j]; n != "0" {
+				out.WriteString(snippet.Placeholder(n, ""))
+			}
+			i = j
+		case c == '$' && i+1 < len(body) && body[i+1] == '{':
+			text, next := parseBraced(body, i+2)
+			out.WriteString(text)
+			i = next
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String(), i
+}
+
+// parseBraced handles the inside of ${...} starting at i and returns the
+// converted text and the index after the closing brace.
+func parseBraced(body string, i int) (string, int) {
+	start := i
+	for i < len(body) && (isDigit(body[i]) || isWordByte(body[i])) {
+		i++
+	}
+	name := body[start:i]
+	if name == "" || i >= len(body) {
+		return "${", start
+	}
+	tabStop := isDigit(name[0])
+
+	switch body[i] {
+	case '}':
+		if !tabStop {
+			return "${" + name + "}", i + 1
+		}
+		if name == "0" {
+			return "", i + 1
+		}
+		return snippet.Placeholder(name, ""), i + 1
+	case ':':
+		def, next := parseTabStops(body, i+1, true)
+		if next < len(body) {
+			next++
+		}
+		// Nested placeholders in a default are flattened to their text
+		def = flattenPlaceholders(def)
+		if !tabStop || name == "0" {
+			return def, next
+		}
+		return snippet.Placeholder(name, def), next
+	case '|':
+		end := strings.Index(body[i:], "|}")
+		if end < 0 {
+			return "${" + name, i
+		}
+		choices := strings.Split(body[i+1:i+end], ",")
+		return snippet.Placeholder(name, choices[0]), i + end + 2
+	}
+
+	// Transforms such as ${TM_FILENAME/(.*)/$1/} are kept as written
+	end := strings.IndexByte(body[i:], '}')
+	if end < 0 {
+		return "${" + name, i
+	}
+	return body[start-2 : i+end+1], i + end + 1
+}
+
+func flattenPlaceholders(s string) string {
+	return snippet.ReplacePlaceholders(s, func(ref snippet.PlaceholderRef) string {
+		if ref.HasDefault {
+			return ref.Default
+		}
+		return ref.Name
+	}, func(text string) string { return text })
+}
+
+// toTabStops turns placeholders into numbered VS Code tab stops, one number
+// per placeholder name. Everything else, including {{...}} that is not a
+// valid placeholder such as Go's {{.Release.Name}}, is kept as literal text
+// with $, } and \ escaped.
+func toTabStops(code string) string {
+	numbers := map[string]int{}
+	return snippet.ReplacePlaceholders(code, func(ref snippet.PlaceholderRef) string {
+		n, ok := numbers[ref.Name]
+		if !ok {
+			n = len(numbers) + 1
+			numbers[ref.Name] = n
+		}
+		def := ref.Default
+		if !ref.HasDefault {
+			if _, err := strconv.Atoi(ref.Name); err == nil {
+				return fmt.Sprintf("$%d", n)
+			}
+			def = ref.Name
+		}
+		return fmt.Sprintf("${%d:%s}", n, escapeSnippetText(def))
+	}, escapeSnippetText)
+}
+
+func escapeSnippetText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`).Replace(s)
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// stripJSONC removes // and /* */ comments and trailing commas so that
+// VS Code's relaxed JSON can be decoded.
+func stripJSONC(data []byte) []byte {
+	var uncommented bytes.Buffer
+	scanJSON(data, func(i int) int {
+		switch {
+		case data[i] == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			return i
+		case data[i] == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return len(data)
+			}
+			return i + end + 4
+		}
+		uncommented.WriteByte(data[i])
+		return i + 1
+	}, &uncommented)
+
+	data = uncommented.Bytes()
+	var out bytes.Buffer
+	scanJSON(data, func(i int) int {
+		if data[i] == ',' {
+			j := i + 1
+			for j < len(data) && strings.ContainsRune(" \t\r\n", rune(data[j])) {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				return i + 1
+			}
+		}
+		out.WriteByte(data[i])
+		return i + 1
+	}, &out)
+	return out.Bytes()
+}
+
+// scanJSON copies string literals in data to out unchanged and calls fn for
+// every other byte; fn writes what it wants kept and returns the next index.
+func scanJSON(data []byte, fn func(i int) int, out *bytes.Buffer) {
+	for i := 0; i < len(data); {
+		if data[i] != '"' {
+			i = fn(i)
+			continue
+		}
+		out.WriteByte('"')
+		i++
+		for i < len(data) {
+			c := data[i]
+			out.WriteByte(c)
+			i++
+			if c == '\\' && i < len(data) {
+				out.WriteByte(data[i])
+				i++
+			} else if c == '"' {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package interop
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// TestVSCodeRoundTrip checks that exporting to VS Code and importing again
+// keeps literal text intact. Placeholder names come back as tab stop numbers.
+func TestVSCodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, code, want string
+	}{
+		{"go template", "name: {{.Release.Name}}", "name: {{.Release.Name}}"},
+		{"spaced template", "value: {{ .Values.image }}", "value: {{ .Values.image }}"},
+		{"shell", `echo "$HOME" ${PATH} } \n`, `echo "$HOME" ${PATH} } \n`},
+		{"placeholder", "git checkout {{branch:main}}", "git checkout {{1:main}}"},
+		{"mixed", "helm install {{release}} --set name={{.Release.Name}}", "helm install {{1:release}} --set name={{.Release.Name}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			in := snippet.NewSnippet("test", tt.code, "", "bash", []string{}, false)
+			if err := WriteVSCode(&buf, []snippet.Snippet{*in}); err != nil {
+				t.Fatal(err)
+			}
+			out, err := ReadVSCode(&buf, "test.code-snippets")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(out) != 1 {
+				t.Fatalf("got %d snippets, want 1", len(out))
+			}
+			if out[0].Code != tt.want {
+				t.Errorf("round trip of %q:\ngot  %q\nwant %q", tt.code, out[0].Code, tt.want)
+			}
+		})
+	}
+}
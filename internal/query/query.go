@@ -0,0 +1,177 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// Query is a parsed snippet filter. Field terms such as "tag:k8s" or
+// "exec:true" must all match; any remaining free text is matched as a single
+// case-insensitive substring against the snippet's ID, title, description,
+// tags, code and language.
+type Query struct {
+	Raw   string
+	Text  string
+	terms []term
+}
+
+type term struct {
+	field  string
+	value  string
+	negate bool
+}
+
+var fieldAliases = map[string]string{
+	"tag":         "tag",
+	"tags":        "tag",
+	"lang":        "language",
+	"language":    "language",
+	"exec":        "executable",
+	"executable":  "executable",
+	"title":       "title",
+	"id":          "id",
+	"desc":        "description",
+	"description": "description",
+	"code":        "code",
+}
+
+// Parse parses a query string. Tokens are separated by whitespace; double
+// quotes group words, and a leading '-' negates a field term.
+func Parse(raw string) (*Query, error) {
+	q := &Query{Raw: raw}
+	var text []string
+
+	for _, tok := range tokenize(raw) {
+		negate := false
+		body := tok
+		if strings.HasPrefix(body, "-") && strings.Contains(body, ":") {
+			negate = true
+			body = body[1:]
+		}
+
+		key, value, ok := strings.Cut(body, ":")
+		field, known := fieldAliases[strings.ToLower(key)]
+		if !ok || !known {
+			text = append(text, tok)
+			continue
+		}
+
+		if value == "" {
+			return nil, fmt.Errorf("empty value for '%s:'", key)
+		}
+		if field == "executable" {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid value '%s' for '%s:', expected true or false", value, key)
+			}
+		}
+		q.terms = append(q.terms, term{field: field, value: value, negate: negate})
+	}
+
+	q.Text = strings.ToLower(strings.Join(text, " "))
+	return q, nil
+}
+
+// Match reports whether s satisfies every term of the query.
+func (q *Query) Match(s snippet.Snippet) bool {
+	for _, t := range q.terms {
+		if t.match(s) == t.negate {
+			return false
+		}
+	}
+	if q.Text != "" && !MatchText(s, q.Text) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the snippets matching the query, preserving order.
+func (q *Query) Filter(snippets []snippet.Snippet) []snippet.Snippet {
+	var matches []snippet.Snippet
+	for _, s := range snippets {
+		if q.Match(s) {
+			matches = append(matches, s)
+		}
+	}
+	return matches
+}
+
+func (t term) match(s snippet.Snippet) bool {
+	value := strings.ToLower(t.value)
+	switch t.field {
+	case "tag":
+		return s.MatchesTag(t.value)
+	case "language":
+		return strings.EqualFold(s.Language, t.value)
+	case "executable":
+		want, _ := strconv.ParseBool(t.value)
+		return s.Executable == want
+	case "title":
+		return strings.Contains(strings.ToLower(s.Title), value)
+	case "id":
+		return snippet.MatchesID(s.ID, value)
+	case "description":
+		return strings.Contains(strings.ToLower(s.Description), value)
+	case "code":
+		return strings.Contains(strings.ToLower(s.Code), value)
+	}
+	return false
+}
+
+// MatchText reports whether the lowercase text appears in any searchable
+// field of s.
+func MatchText(s snippet.Snippet, text string) bool {
+	if strings.Contains(strings.ToLower(s.ID), text) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(s.Title), text) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(s.Description), text) {
+		return true
+	}
+	for _, tag := range s.Tags {
+		if strings.Contains(strings.ToLower(tag), text) {
+			return true
+		}
+	}
+	if strings.Contains(strings.ToLower(s.Code), text) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(s.Language), text) {
+		return true
+	}
+	return false
+}
+
+// tokenize splits raw on whitespace, keeping double-quoted sections together
+// and dropping the quotes.
+func tokenize(raw string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	started := false
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			started = true
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuotes:
+			if started {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// SortKeys lists the keys accepted by ParseSort.
+var SortKeys = []string{"title", "created", "last-used", "usage", "language", "frecency"}
+
+// defaultDesc holds the keys that sort descending unless asked otherwise, so
+// that "--sort usage" puts the most used snippets first.
+var defaultDesc = map[string]bool{
+	"created":   true,
+	"last-used": true,
+	"usage":     true,
+	"frecency":  true,
+}
+
+// SortSpec describes how to order snippets.
+type SortSpec struct {
+	Key  string
+	Desc bool
+}
+
+// ParseSort parses "key", "key:asc" or "key:desc".
+func ParseSort(raw string) (SortSpec, error) {
+	key, dir, hasDir := strings.Cut(strings.ToLower(strings.TrimSpace(raw)), ":")
+	key = strings.ReplaceAll(key, "_", "-")
+	if key == "lastused" || key == "used" {
+		key = "last-used"
+	}
+
+	valid := false
+	for _, k := range SortKeys {
+		if k == key {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return SortSpec{}, fmt.Errorf("unknown sort key '%s'. Valid keys: %s", key, strings.Join(SortKeys, ", "))
+	}
+
+	spec := SortSpec{Key: key, Desc: defaultDesc[key]}
+	if hasDir {
+		switch dir {
+		case "asc":
+			spec.Desc = false
+		case "desc":
+			spec.Desc = true
+		default:
+			return SortSpec{}, fmt.Errorf("unknown sort direction '%s', expected asc or desc", dir)
+		}
+	}
+	return spec, nil
+}
+
+// Sort orders snippets in place according to spec. Frecency scores are keyed
+// by snippet ID and only consulted for the frecency key. The sort is stable,
+// so snippets that compare equal keep their insertion order.
+func Sort(snippets []snippet.Snippet, spec SortSpec, frecency map[string]float64) {
+	less := func(a, b snippet.Snippet) bool {
+		switch spec.Key {
+		case "title":
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		case "created":
+			return a.CreatedAt < b.CreatedAt
+		case "last-used":
+			return a.LastUsed < b.LastUsed
+		case "usage":
+			return a.UsageCount < b.UsageCount
+		case "language":
+			return strings.ToLower(a.Language) < strings.ToLower(b.Language)
+		case "frecency":
+			return frecency[a.ID] < frecency[b.ID]
+		}
+		return false
+	}
+
+	sort.SliceStable(snippets, func(i, j int) bool {
+		if spec.Desc {
+			return less(snippets[j], snippets[i])
+		}
+		return less(snippets[i], snippets[j])
+	})
+}
+
+// Paginate returns at most limit snippets starting at offset. A limit of zero
+// or less means no limit.
+func Paginate(snippets []snippet.Snippet, offset, limit int) []snippet.Snippet {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(snippets) {
+		return nil
+	}
+	snippets = snippets[offset:]
+	if limit > 0 && limit < len(snippets) {
+		snippets = snippets[:limit]
+	}
+	return snippets
+}
@@ -0,0 +1,173 @@
+package render
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/highlight"
+)
+
+type htmlPage struct {
+	Title     string
+	Generated string
+	Count     string
+	Groups    []htmlGroup
+}
+
+type htmlGroup struct {
+	Name     string
+	Anchor   string
+	Snippets []htmlSnippet
+}
+
+type htmlSnippet struct {
+	Anchor      string
+	ID          string
+	Title       string
+	Language    string
+	Description string
+	Tags        []string
+	Executable  bool
+	Code        template.HTML
+	// Search is the lower-cased text matched by the filter box.
+	Search string
+}
+
+// HTML writes groups as a single self-contained page: styles, highlighted
+// code and the client-side filter are all inline.
+func HTML(w io.Writer, groups []Group, total int, opts Options) error {
+	page := htmlPage{Title: opts.Title, Count: snippetCount(total)}
+	if !opts.Generated.IsZero() {
+		page.Generated = opts.Generated.Format("2006-01-02")
+	}
+
+	for _, g := range groups {
+		hg := htmlGroup{Name: g.Name, Anchor: g.Anchor}
+		for _, s := range g.Snippets {
+			code, err := highlight.HTML(s.Code, s.Language, opts.Theme)
+			if err != nil {
+				return err
+			}
+			hg.Snippets = append(hg.Snippets, htmlSnippet{
+				Anchor:      snippetAnchor(g, s),
+				ID:          s.ID,
+				Title:       s.Title,
+				Language:    s.Language,
+				Description: s.Description,
+				Tags:        s.Tags,
+				Executable:  s.Executable,
+				Code:        template.HTML(code),
+				Search:      strings.ToLower(strings.Join(append([]string{s.ID, s.Title, s.Language, s.Description, s.Code}, s.Tags...), " ")),
+			})
+		}
+		page.Groups = append(page.Groups, hg)
+	}
+
+	return pageTemplate.Execute(w, page)
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; background: #f6f8fa; }
+  header { background: #24292f; color: #fff; padding: 1.5rem 2rem; }
+  header h1 { margin: 0 0 .25rem; }
+  header p { margin: 0; opacity: .8; }
+  .layout { display: flex; align-items: flex-start; }
+  nav { position: sticky; top: 0; width: 16rem; max-height: 100vh; overflow-y: auto; padding: 1rem; box-sizing: border-box; }
+  nav ul { list-style: none; padding-left: 0; margin: 0; }
+  nav ul ul { padding-left: 1rem; margin-bottom: .5rem; }
+  nav a { color: #0969da; text-decoration: none; }
+  nav a:hover { text-decoration: underline; }
+  main { flex: 1; padding: 1rem 2rem; min-width: 0; }
+  #filter { width: 100%; padding: .5rem .75rem; font-size: 1rem; box-sizing: border-box; border: 1px solid #d0d7de; border-radius: 6px; margin-bottom: .5rem; }
+  .empty { color: #57606a; display: none; }
+  section h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .25rem; }
+  article { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 1rem; margin-bottom: 1rem; }
+  article h3 { margin: 0 0 .5rem; }
+  .meta { font-size: .85rem; color: #57606a; margin-bottom: .5rem; }
+  .badge { display: inline-block; padding: 0 .5rem; border-radius: 1rem; background: #eaeef2; margin-right: .25rem; }
+  .badge.exec { background: #dafbe1; color: #1a7f37; font-weight: 600; }
+  .tag { color: #0969da; }
+  pre { padding: .75rem; border-radius: 6px; overflow-x: auto; margin: .5rem 0 0; }
+  .hidden { display: none; }
+</style>
+</head>
+<body>
+<header>
+  <h1>{{.Title}}</h1>
+  <p>{{.Count}}{{if .Generated}} · generated {{.Generated}}{{end}}</p>
+</header>
+<div class="layout">
+<nav>
+  <ul>
+  {{- range .Groups}}
+    <li data-group="{{.Anchor}}"><a href="#{{.Anchor}}">{{.Name}}</a> ({{len .Snippets}})
+      <ul>
+      {{- range .Snippets}}
+        <li data-target="{{.Anchor}}"><a href="#{{.Anchor}}">{{.Title}}</a></li>
+      {{- end}}
+      </ul>
+    </li>
+  {{- end}}
+  </ul>
+</nav>
+<main>
+  <input id="filter" type="search" placeholder="Filter by title, tag, language or code…" autofocus>
+  <p class="empty" id="empty">No snippets match the filter.</p>
+  {{- range .Groups}}
+  <section id="{{.Anchor}}">
+    <h2>{{.Name}}</h2>
+    {{- range .Snippets}}
+    <article id="{{.Anchor}}" data-search="{{.Search}}">
+      <h3>{{.Title}}</h3>
+      <div class="meta">
+        {{- if .Language}}<span class="badge">{{.Language}}</span>{{end}}
+        {{- if .Executable}}<span class="badge exec">▶ executable</span>{{end}}
+        <span class="badge">id {{.ID}}</span>
+        {{- range .Tags}} <span class="tag">#{{.}}</span>{{end}}
+      </div>
+      {{- if .Description}}
+      <p>{{.Description}}</p>
+      {{- end}}
+      {{.Code}}
+    </article>
+    {{- end}}
+  </section>
+  {{- end}}
+</main>
+</div>
+<script>
+(function () {
+  var input = document.getElementById("filter");
+  function apply() {
+    var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    var anyVisible = false;
+    document.querySelectorAll("section").forEach(function (section) {
+      var visible = 0;
+      section.querySelectorAll("article").forEach(function (article) {
+        var text = article.getAttribute("data-search");
+        var match = terms.every(function (t) { return text.indexOf(t) !== -1; });
+        article.classList.toggle("hidden", !match);
+        var link = document.querySelector('nav li[data-target="' + article.id + '"]');
+        if (link) { link.classList.toggle("hidden", !match); }
+        if (match) { visible++; }
+      });
+      section.classList.toggle("hidden", visible === 0);
+      var group = document.querySelector('nav li[data-group="' + section.id + '"]');
+      if (group) { group.classList.toggle("hidden", visible === 0); }
+      if (visible > 0) { anyVisible = true; }
+    });
+    document.getElementById("empty").style.display = anyVisible ? "none" : "block";
+  }
+  input.addEventListener("input", apply);
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,111 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/highlight"
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// Markdown writes groups as a Markdown document with a table of contents and
+// fenced code blocks tagged with each snippet's language.
+func Markdown(w io.Writer, groups []Group, total int, opts Options) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "# %s\n\n", escapeMarkdown(opts.Title))
+	fmt.Fprintf(out, "_%s", snippetCount(total))
+	if !opts.Generated.IsZero() {
+		fmt.Fprintf(out, " · generated %s", opts.Generated.Format("2006-01-02"))
+	}
+	fmt.Fprint(out, "_\n\n")
+
+	if len(groups) > 0 {
+		fmt.Fprint(out, "## Contents\n\n")
+		for _, g := range groups {
+			fmt.Fprintf(out, "- [%s](#%s) (%d)\n", escapeMarkdown(g.Name), g.Anchor, len(g.Snippets))
+			for _, s := range g.Snippets {
+				fmt.Fprintf(out, "  - [%s](#%s)\n", escapeMarkdown(s.Title), snippetAnchor(g, s))
+			}
+		}
+		fmt.Fprintln(out)
+	}
+
+	for _, g := range groups {
+		fmt.Fprintf(out, "<a id=\"%s\"></a>\n\n## %s\n\n", g.Anchor, escapeMarkdown(g.Name))
+		for _, s := range g.Snippets {
+			writeMarkdownSnippet(out, g, s)
+		}
+	}
+
+	return out.Flush()
+}
+
+func writeMarkdownSnippet(out *bufio.Writer, g Group, s snippet.Snippet) {
+	fmt.Fprintf(out, "<a id=\"%s\"></a>\n\n### %s\n\n", snippetAnchor(g, s), escapeMarkdown(s.Title))
+
+	var badges []string
+	if s.Language != "" {
+		badges = append(badges, "`"+s.Language+"`")
+	}
+	if s.Executable {
+		badges = append(badges, "**▶ executable**")
+	}
+	badges = append(badges, "id `"+s.ID+"`")
+	fmt.Fprintln(out, strings.Join(badges, " · "))
+	fmt.Fprintln(out)
+
+	if s.Description != "" {
+		fmt.Fprintf(out, "%s\n\n", escapeMarkdown(s.Description))
+	}
+
+	if len(s.Tags) > 0 {
+		tags := make([]string, len(s.Tags))
+		for i, t := range s.Tags {
+			tags[i] = "`#" + t + "`"
+		}
+		fmt.Fprintf(out, "Tags: %s\n\n", strings.Join(tags, " "))
+	}
+
+	fence := codeFence(s.Code)
+	info := s.Language
+	if info == "" {
+		info = highlight.LexerName(s.Code, "")
+	}
+	fmt.Fprintf(out, "%s%s\n%s", fence, info, s.Code)
+	if !strings.HasSuffix(s.Code, "\n") {
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "%s\n\n", fence)
+}
+
+// codeFence returns a backtick fence longer than any backtick run in code, so
+// code containing ``` is not cut short.
+func codeFence(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`,
+)
+
+// escapeMarkdown keeps titles and descriptions from being read as formatting.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,172 @@
+// Package render turns snippets into shareable Markdown or HTML cheatsheets.
+package render
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// Supported output formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Supported groupings.
+const (
+	GroupByTag      = "tag"
+	GroupByLanguage = "language"
+	GroupByNone     = "none"
+)
+
+// Formats and Groupings list the accepted values, for flag help and errors.
+var (
+	Formats   = []string{FormatMarkdown, FormatHTML}
+	Groupings = []string{GroupByTag, GroupByLanguage, GroupByNone}
+)
+
+// Options controls how a cheatsheet is rendered.
+type Options struct {
+	Title   string
+	GroupBy string
+	// Theme is the highlighting theme used for HTML code blocks.
+	Theme string
+	// Generated is shown in the page header; the zero time omits it.
+	Generated time.Time
+}
+
+// Group is a titled section of the cheatsheet.
+type Group struct {
+	Name     string
+	Anchor   string
+	Snippets []snippet.Snippet
+}
+
+const (
+	untaggedGroup   = "untagged"
+	noLanguageGroup = "other"
+)
+
+// Render writes snippets as a cheatsheet in the given format.
+func Render(w io.Writer, format string, snippets []snippet.Snippet, opts Options) error {
+	groups, err := GroupSnippets(snippets, opts.GroupBy)
+	if err != nil {
+		return err
+	}
+	if opts.Title == "" {
+		opts.Title = "CodeStash Cheatsheet"
+	}
+
+	switch strings.ToLower(format) {
+	case FormatMarkdown, "md":
+		return Markdown(w, groups, len(snippets), opts)
+	case FormatHTML:
+		return HTML(w, groups, len(snippets), opts)
+	}
+	return fmt.Errorf("unknown format '%s', expected %s", format, strings.Join(Formats, " or "))
+}
+
+// GroupSnippets splits snippets into sections sorted by name, with snippets
+// sorted by title inside each. Grouping by tag puts a snippet in every one of
+// its tags' sections.
+func GroupSnippets(snippets []snippet.Snippet, groupBy string) ([]Group, error) {
+	sorted := append([]snippet.Snippet(nil), snippets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Title) < strings.ToLower(sorted[j].Title)
+	})
+
+	byName := map[string][]snippet.Snippet{}
+	var fallback string
+	switch strings.ToLower(groupBy) {
+	case GroupByTag:
+		fallback = untaggedGroup
+		for _, s := range sorted {
+			if len(s.Tags) == 0 {
+				byName[untaggedGroup] = append(byName[untaggedGroup], s)
+				continue
+			}
+			seen := map[string]bool{}
+			for _, tag := range s.Tags {
+				tag = strings.TrimSpace(tag)
+				if tag == "" || seen[tag] {
+					continue
+				}
+				seen[tag] = true
+				byName[tag] = append(byName[tag], s)
+			}
+		}
+	case GroupByLanguage, "lang":
+		fallback = noLanguageGroup
+		for _, s := range sorted {
+			lang := strings.TrimSpace(s.Language)
+			if lang == "" {
+				lang = noLanguageGroup
+			}
+			byName[lang] = append(byName[lang], s)
+		}
+	case GroupByNone, "":
+		if len(sorted) == 0 {
+			return nil, nil
+		}
+		return []Group{{Name: "Snippets", Anchor: "snippets", Snippets: sorted}}, nil
+	default:
+		return nil, fmt.Errorf("unknown grouping '%s', expected %s", groupBy, strings.Join(Groupings, ", "))
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	// Keep the catch-all section last
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == fallback) != (names[j] == fallback) {
+			return names[j] == fallback
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	groups := make([]Group, len(names))
+	used := map[string]bool{}
+	for i, name := range names {
+		groups[i] = Group{Name: name, Anchor: uniqueAnchor(slugify(name), used), Snippets: byName[name]}
+	}
+	return groups, nil
+}
+
+// snippetAnchor identifies a snippet within a group; a snippet can appear in
+// several groups when grouping by tag.
+func snippetAnchor(g Group, s snippet.Snippet) string {
+	return g.Anchor + "-" + slugify(s.ID)
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if slug == "" {
+		return "section"
+	}
+	return slug
+}
+
+func uniqueAnchor(anchor string, used map[string]bool) string {
+	candidate := anchor
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", anchor, n)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func snippetCount(n int) string {
+	if n == 1 {
+		return "1 snippet"
+	}
+	return fmt.Sprintf("%d snippets", n)
+}
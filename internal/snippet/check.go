@@ -0,0 +1,138 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Problem severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem is one issue found by an integrity check.
+type Problem struct {
+	Check    string `json:"check" yaml:"check"`
+	Severity string `json:"severity" yaml:"severity"`
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"`
+	Title    string `json:"title,omitempty" yaml:"title,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+	// Fixable problems can be repaired without losing data; Fixed is set
+	// once they have been.
+	Fixable bool `json:"fixable" yaml:"fixable"`
+	Fixed   bool `json:"fixed" yaml:"fixed"`
+}
+
+// timestampLayouts are the layouts a damaged or hand-edited timestamp is
+// recovered from, besides RFC 3339.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.UnixDate,
+}
+
+// CheckSnippets looks for duplicate or missing IDs, empty titles or code and
+// unparseable timestamps. With fix set, problems that can be repaired safely
+// are fixed in place: IDs are regenerated, titles are taken from the first
+// line of code and timestamps are reformatted, or cleared when they cannot
+// be read. Empty code is only reported.
+func CheckSnippets(snippets []Snippet, fix bool) []Problem {
+	var problems []Problem
+	// repair fixes the problem and describes what it did
+	report := func(s *Snippet, check, severity, message string, repair func() string) {
+		p := Problem{Check: check, Severity: severity, ID: s.ID, Title: s.Title, Message: message, Fixable: repair != nil}
+		if fix && repair != nil {
+			p.Message += "; " + repair()
+			p.Fixed = true
+		}
+		problems = append(problems, p)
+	}
+
+	seen := make(map[string]bool, len(snippets))
+	for i := range snippets {
+		s := &snippets[i]
+
+		switch {
+		case strings.TrimSpace(s.ID) == "":
+			report(s, "missing_id", SeverityError, "snippet has no ID", func() string {
+				s.ID = UniqueID(seen)
+				return "assigned ID " + s.ID
+			})
+		case seen[s.ID]:
+			report(s, "duplicate_id", SeverityError, fmt.Sprintf("ID %s is used by more than one snippet", s.ID), func() string {
+				s.ID = UniqueID(seen)
+				return "changed this one's ID to " + s.ID
+			})
+		}
+		seen[s.ID] = true
+
+		if strings.TrimSpace(s.Title) == "" {
+			report(s, "empty_title", SeverityError, "title is empty", func() string {
+				s.Title = titleFromCode(s.Code)
+				return fmt.Sprintf("set it to '%s'", s.Title)
+			})
+		}
+		if strings.TrimSpace(s.Code) == "" {
+			report(s, "empty_code", SeverityError, "code is empty", nil)
+		}
+
+		if fixed, ok := checkTimestamp(s.CreatedAt); !ok {
+			if fixed == "" {
+				// Creation times are required; fall back to now
+				fixed = time.Now().UTC().Format(time.RFC3339)
+			}
+			report(s, "bad_timestamp", SeverityWarning, fmt.Sprintf("created_at %q is not a valid timestamp", s.CreatedAt), func() string {
+				s.CreatedAt = fixed
+				return "set it to " + fixed
+			})
+		}
+		if s.LastUsed != "" {
+			if fixed, ok := checkTimestamp(s.LastUsed); !ok {
+				report(s, "bad_timestamp", SeverityWarning, fmt.Sprintf("last_used %q is not a valid timestamp", s.LastUsed), func() string {
+					s.LastUsed = fixed
+					if fixed == "" {
+						return "cleared it"
+					}
+					return "set it to " + fixed
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// checkTimestamp reports whether value is an RFC 3339 timestamp. If it is
+// not, the value is returned in RFC 3339 form when another layout can read
+// it, or empty.
+func checkTimestamp(value string) (string, bool) {
+	if _, ok := parseTime(value); ok {
+		return value, true
+	}
+	value = strings.TrimSpace(value)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339), false
+		}
+	}
+	return "", false
+}
+
+// titleFromCode uses the first non-empty line of code as a title.
+func titleFromCode(code string) string {
+	for _, line := range strings.Split(code, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if r := []rune(line); len(r) > 60 {
+				return string(r[:57]) + "..."
+			}
+			return line
+		}
+	}
+	return "Untitled"
+}
@@ -0,0 +1,26 @@
+package snippet
+
+import (
+	"time"
+)
+
+// Collection is a saved search: a named query that is evaluated against the
+// current snippets every time it is used.
+type Collection struct {
+	Name        string `json:"name"`
+	Query       string `json:"query"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func NewCollection(name, query, desc string) *Collection {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return &Collection{
+		Name:        name,
+		Query:       query,
+		Description: desc,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
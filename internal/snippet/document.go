@@ -0,0 +1,150 @@
+package snippet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter holds the fields edited in the YAML header of a snippet
+// document. The code follows the header.
+type frontMatter struct {
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Language    string   `yaml:"language"`
+	Tags        []string `yaml:"tags,flow"`
+	Executable  bool     `yaml:"executable"`
+}
+
+// documentFields are the front matter keys, in document order.
+var documentFields = []string{"title", "description", "language", "tags", "executable"}
+
+func isDocumentField(name string) bool {
+	for _, f := range documentFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	frontMatterFence = "---"
+	// notePrefix marks comment lines added by AnnotateDocument so they can be
+	// replaced when the document is annotated again.
+	notePrefix = "# codestash: "
+)
+
+// FormatDocument renders s as a document for editing in a text editor: a
+// YAML front matter block with the editable fields, followed by the code.
+func FormatDocument(s Snippet) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterFence + "\n")
+
+	tags := s.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	header, _ := yaml.Marshal(frontMatter{
+		Title:       s.Title,
+		Description: s.Description,
+		Language:    s.Language,
+		Tags:        tags,
+		Executable:  s.Executable,
+	})
+	buf.Write(header)
+	buf.WriteString(frontMatterFence + "\n")
+	buf.WriteString(s.Code)
+	if s.Code != "" && !strings.HasSuffix(s.Code, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// AnnotateDocument replaces the notes at the top of a document with note,
+// e.g. to explain why it could not be parsed. Notes are YAML comments, so
+// ParseDocument ignores them.
+func AnnotateDocument(data []byte, note string) []byte {
+	var kept []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, notePrefix) {
+			kept = append(kept, line)
+		}
+	}
+
+	var notes []string
+	for _, line := range strings.Split(note, "\n") {
+		notes = append(notes, notePrefix+line)
+	}
+
+	// Keep the notes inside the front matter when there is one
+	if len(kept) > 0 && kept[0] == frontMatterFence {
+		return []byte(strings.Join(append(append([]string{frontMatterFence}, notes...), kept[1:]...), "\n"))
+	}
+	return []byte(strings.Join(append(notes, kept...), "\n"))
+}
+
+// ParseDocument reads a document written by FormatDocument and applies its
+// fields to s. Unknown fields, a missing title or empty code are errors, and
+// s is left unchanged when an error is returned.
+func ParseDocument(data []byte, s *Snippet) error {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	for strings.HasPrefix(text, notePrefix) {
+		_, text, _ = strings.Cut(text, "\n")
+	}
+	rest, ok := strings.CutPrefix(text, frontMatterFence+"\n")
+	if !ok {
+		return fmt.Errorf("the document must start with a '---' line")
+	}
+	header, code, ok := strings.Cut(rest, "\n"+frontMatterFence+"\n")
+	if after, empty := strings.CutPrefix(rest, frontMatterFence+"\n"); empty {
+		header, code, ok = "", after, true
+	}
+	if !ok {
+		// The closing fence may be the last line
+		if header, ok = strings.CutSuffix(rest, "\n"+frontMatterFence); !ok {
+			return fmt.Errorf("the front matter must end with a '---' line")
+		}
+		code = ""
+	}
+
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(header), &fields); err != nil {
+		return fmt.Errorf("invalid front matter: %v", err)
+	}
+	for name := range fields {
+		if !isDocumentField(name) {
+			return fmt.Errorf("unknown field '%s'. Valid fields: %s", name, strings.Join(documentFields, ", "))
+		}
+	}
+
+	var fm frontMatter
+	if err := yaml.NewDecoder(strings.NewReader(header)).Decode(&fm); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid front matter: %v", err)
+	}
+
+	fm.Title = strings.TrimSpace(fm.Title)
+	if fm.Title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+	if strings.TrimSpace(code) == "" {
+		return fmt.Errorf("code cannot be empty")
+	}
+
+	// Editors add a final newline; drop it if the snippet had none
+	if !strings.HasSuffix(s.Code, "\n") && s.Code != "" {
+		code = strings.TrimSuffix(code, "\n")
+	}
+
+	s.Title = fm.Title
+	s.Description = strings.TrimSpace(fm.Description)
+	s.Language = strings.TrimSpace(fm.Language)
+	s.Tags = NormalizeTags(fm.Tags)
+	s.Executable = fm.Executable
+	s.Code = code
+	return nil
+}
@@ -0,0 +1,101 @@
+package snippet
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EditableFields are the fields that can be changed with SetField, named by
+// their JSON keys.
+var EditableFields = []string{"title", "description", "language", "tags", "executable", "code"}
+
+// SetField sets one field from its text form. Tags are comma separated and
+// executable takes true/false, yes/no or 1/0. Fields that exist on the model
+// but are managed by codestash, such as id or usage_count, are rejected.
+func (s *Snippet) SetField(name, value string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "title":
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+		s.Title = value
+	case "description":
+		s.Description = strings.TrimSpace(value)
+	case "language":
+		s.Language = strings.TrimSpace(value)
+	case "tags":
+		s.Tags = NormalizeTags(strings.Split(value, ","))
+	case "executable":
+		b, err := ParseBool(value)
+		if err != nil {
+			return err
+		}
+		s.Executable = b
+	case "code":
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("code cannot be empty")
+		}
+		s.Code = value
+	default:
+		if modelHasField(name) {
+			return fmt.Errorf("field '%s' is managed by codestash and cannot be set", name)
+		}
+		return fmt.Errorf("unknown field '%s'. Valid fields: %s", name, strings.Join(EditableFields, ", "))
+	}
+	return nil
+}
+
+// AddTag adds tag, normalized, unless the snippet already has it, and
+// reports whether it was added.
+func (s *Snippet) AddTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	if tag == "" || s.HasTag(tag) {
+		return false
+	}
+	s.Tags = append(s.Tags, tag)
+	return true
+}
+
+// RemoveTag removes tag, ignoring case and whitespace, and reports whether it
+// was present.
+func (s *Snippet) RemoveTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for i, t := range s.Tags {
+		if NormalizeTag(t) == tag {
+			s.Tags = append(s.Tags[:i], s.Tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBool accepts true/false, yes/no, y/n and 1/0.
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean '%s', expected true or false", value)
+	}
+	return b, nil
+}
+
+// modelHasField reports whether Snippet has a field with the JSON key name.
+func modelHasField(name string) bool {
+	t := reflect.TypeOf(Snippet{})
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
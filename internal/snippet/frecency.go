@@ -0,0 +1,78 @@
+package snippet
+
+import (
+	"math"
+	"time"
+)
+
+// FrecencyHalfLife is the age at which a use counts half as much as a use
+// made right now.
+const FrecencyHalfLife = 14 * 24 * time.Hour
+
+// Usage commands recorded in the usage event log.
+const (
+	UsagePrint = "print"
+	UsageCopy  = "copy"
+	UsageExec  = "exec"
+)
+
+// UsageEvent records a single use of a snippet.
+type UsageEvent struct {
+	SnippetID string `json:"snippet_id"`
+	Command   string `json:"command"`
+	Time      string `json:"time"`
+}
+
+func NewUsageEvent(id, command string) UsageEvent {
+	return UsageEvent{
+		SnippetID: id,
+		Command:   command,
+		Time:      time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// FrecencyScores combines how often and how recently each snippet was used
+// into a single score, keyed by snippet ID. Every recorded event contributes
+// a weight that halves each FrecencyHalfLife. Uses counted in UsageCount but
+// missing from the log (made before the log existed) are assumed to have
+// happened no later than the snippet's first logged use, or LastUsed if it
+// has none.
+func FrecencyScores(snippets []Snippet, events []UsageEvent, now time.Time) map[string]float64 {
+	scores := make(map[string]float64, len(snippets))
+	logged := make(map[string]int)
+	firstLogged := make(map[string]time.Time)
+
+	for _, e := range events {
+		t, ok := parseTime(e.Time)
+		if !ok {
+			continue
+		}
+		scores[e.SnippetID] += decay(now.Sub(t))
+		logged[e.SnippetID]++
+		if first, ok := firstLogged[e.SnippetID]; !ok || t.Before(first) {
+			firstLogged[e.SnippetID] = t
+		}
+	}
+
+	for _, s := range snippets {
+		unlogged := s.UsageCount - logged[s.ID]
+		if unlogged <= 0 {
+			continue
+		}
+		when, ok := firstLogged[s.ID]
+		if !ok {
+			if when, ok = parseTime(s.LastUsed); !ok {
+				continue
+			}
+		}
+		scores[s.ID] += float64(unlogged) * decay(now.Sub(when))
+	}
+	return scores
+}
+
+func decay(age time.Duration) float64 {
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, float64(age)/float64(FrecencyHalfLife))
+}
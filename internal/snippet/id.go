@@ -2,11 +2,141 @@ package snippet
 
 import (
 	"crypto/rand"
-	"encoding/hex"
+	"encoding/binary"
+	"strings"
+	"time"
 )
 
+// IDs are ULID-style: a 48-bit millisecond timestamp followed by 80 random
+// bits, written as 26 lowercase Crockford base32 characters. They sort by
+// creation time. IDs made before this scheme (8 hex characters) are still
+// valid and keep resolving.
+const (
+	IDLength = 26
+	// ShortIDLength is the shortest alias shown for an ID.
+	ShortIDLength = 8
+
+	// idTimeChars encode the timestamp (and two random bits)
+	idTimeChars = 10
+	// aliasTimeChars of the timestamp start an alias, narrowing it to a
+	// window of about 17 minutes; the random characters that follow tell
+	// apart snippets created together, such as in one import.
+	aliasTimeChars = 6
+)
+
+const crockford = "0123456789abcdefghjkmnpqrstvwxyz"
+
 func generateID() string {
-	b := make([]byte, 4)
-	_, _ = rand.Read(b)
-	return hex.EncodeToString(b)
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	// Since Go 1.24 Read never returns an error; it crashes instead
+	rand.Read(b[6:])
+	return encodeID(b)
+}
+
+// encodeID writes the 128 bits of b as 26 base32 characters, most
+// significant first, with two leading zero bits.
+func encodeID(b [16]byte) string {
+	bit := func(i int) byte {
+		if i < 0 {
+			return 0
+		}
+		return b[i/8] >> (7 - i%8) & 1
+	}
+	out := make([]byte, IDLength)
+	for i := range out {
+		var v byte
+		for j := 0; j < 5; j++ {
+			v = v<<1 | bit(i*5+j-2)
+		}
+		out[i] = crockford[v]
+	}
+	return string(out)
+}
+
+// UniqueID returns a fresh ID that taken does not contain.
+func UniqueID(taken map[string]bool) string {
+	id := generateID()
+	for taken[strings.ToLower(id)] {
+		id = generateID()
+	}
+	return id
+}
+
+// EnsureUniqueID gives s a new ID if another snippet in existing already
+// uses it, ignoring case as Resolve does, and reports whether it did.
+func (s *Snippet) EnsureUniqueID(existing []Snippet) bool {
+	taken := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		taken[strings.ToLower(e.ID)] = true
+	}
+	if !taken[strings.ToLower(s.ID)] {
+		return false
+	}
+	s.ID = UniqueID(taken)
+	return true
+}
+
+// MatchesID reports whether ref refers to id, ignoring case: as a prefix of
+// it, or for a 26-character ID, as a short alias made of its first
+// characters followed by a prefix of its random part.
+func MatchesID(id, ref string) bool {
+	id, ref = strings.ToLower(id), strings.ToLower(ref)
+	if strings.HasPrefix(id, ref) {
+		return true
+	}
+	return len(id) == IDLength && len(ref) > aliasTimeChars &&
+		id[:aliasTimeChars] == ref[:aliasTimeChars] &&
+		strings.HasPrefix(id[idTimeChars:], ref[aliasTimeChars:])
+}
+
+// ShortIDs maps each of ids to the shortest alias of at least ShortIDLength
+// characters that MatchesID matches to no other ID, so it can be passed back
+// to any command. For a 26-character ID that is its first six characters and
+// the start of its random part; older IDs are shortened to a prefix. An ID
+// that cannot be shortened is its own alias.
+func ShortIDs(ids []string) map[string]string {
+	// Both kinds of match share the first characters
+	buckets := make(map[string][]string)
+	for _, id := range ids {
+		key := strings.ToLower(id[:min(aliasTimeChars, len(id))])
+		buckets[key] = append(buckets[key], id)
+	}
+
+	short := make(map[string]string, len(ids))
+	for _, id := range ids {
+		bucket := buckets[strings.ToLower(id[:min(aliasTimeChars, len(id))])]
+		short[id] = id
+		for _, alias := range aliasCandidates(id) {
+			if uniqueAlias(alias, id, bucket) {
+				short[id] = alias
+				break
+			}
+		}
+	}
+	return short
+}
+
+// aliasCandidates lists the aliases for id, shortest first.
+func aliasCandidates(id string) []string {
+	var aliases []string
+	if len(id) == IDLength {
+		for n := ShortIDLength - aliasTimeChars; idTimeChars+n < len(id); n++ {
+			aliases = append(aliases, id[:aliasTimeChars]+id[idTimeChars:idTimeChars+n])
+		}
+		return aliases
+	}
+	for n := ShortIDLength; n < len(id); n++ {
+		aliases = append(aliases, id[:n])
+	}
+	return aliases
+}
+
+func uniqueAlias(alias, id string, others []string) bool {
+	for _, other := range others {
+		if !strings.EqualFold(other, id) && MatchesID(other, alias) {
+			return false
+		}
+	}
+	return true
 }
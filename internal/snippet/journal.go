@@ -0,0 +1,230 @@
+package snippet
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JournalEntry records one change to the stash, with enough of the before
+// and after state to reverse it.
+type JournalEntry struct {
+	ID      string          `json:"id"`
+	Time    string          `json:"time"`
+	Command string          `json:"command"`
+	Summary string          `json:"summary"`
+	Changes []SnippetChange `json:"changes"`
+	// Undone marks an entry reverted by undo. It can be redone until a new
+	// change is recorded.
+	Undone bool `json:"undone,omitempty"`
+}
+
+// SnippetChange is the before and after state of one snippet. Before is nil
+// for an added snippet and After is nil for a deleted one.
+type SnippetChange struct {
+	ID string `json:"id"`
+	// Index is the snippet's position before the change, so a deleted
+	// snippet can be restored in place.
+	Index  int      `json:"index"`
+	Before *Snippet `json:"before,omitempty"`
+	After  *Snippet `json:"after,omitempty"`
+}
+
+// NewJournalEntry describes the change from before to after, or returns nil
+// when nothing changed. Snippets are matched by ID.
+func NewJournalEntry(command, summary string, before, after []Snippet) *JournalEntry {
+	changes := Diff(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	return &JournalEntry{
+		ID:      generateID(),
+		Time:    time.Now().UTC().Format(time.RFC3339),
+		Command: command,
+		Summary: summary,
+		Changes: changes,
+	}
+}
+
+// Diff lists the snippets that were added, changed or removed between
+// before and after.
+func Diff(before, after []Snippet) []SnippetChange {
+	afterByID := make(map[string]int, len(after))
+	for i, s := range after {
+		afterByID[s.ID] = i
+	}
+
+	var changes []SnippetChange
+	seen := make(map[string]bool, len(before))
+	for i := range before {
+		old := before[i]
+		seen[old.ID] = true
+		j, ok := afterByID[old.ID]
+		if !ok {
+			changes = append(changes, SnippetChange{ID: old.ID, Index: i, Before: &old})
+			continue
+		}
+		if updated := after[j]; !reflect.DeepEqual(old, updated) {
+			changes = append(changes, SnippetChange{ID: old.ID, Index: i, Before: &old, After: &updated})
+		}
+	}
+	for i := range after {
+		if added := after[i]; !seen[added.ID] {
+			changes = append(changes, SnippetChange{ID: added.ID, Index: i, After: &added})
+		}
+	}
+	return changes
+}
+
+// ChangedFields lists the JSON keys of the fields that differ between a and
+// b, in model order.
+func ChangedFields(a, b Snippet) []string {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	t := va.Type()
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, key)
+		}
+	}
+	return fields
+}
+
+// SameContent reports whether a and b have the same title, code, tags (in
+// any order), language, description and executable flag. The ID, creation
+// time and usage fields are not compared: usage changes whenever a snippet
+// is used, so it is not part of the journal's conflict checks, and uses made
+// after a change survive its undo.
+func SameContent(a, b Snippet) bool {
+	if a.Title != b.Title || a.Code != b.Code || a.Language != b.Language ||
+		a.Description != b.Description || a.Executable != b.Executable || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	tags := make(map[string]int, len(a.Tags))
+	for _, t := range a.Tags {
+		tags[t]++
+	}
+	for _, t := range b.Tags {
+		if tags[t] == 0 {
+			return false
+		}
+		tags[t]--
+	}
+	return true
+}
+
+// UsageOnly reports whether after differs from before only in usage fields,
+// as when a snippet has just been printed, copied or run.
+func UsageOnly(before, after []Snippet) bool {
+	for _, c := range Diff(before, after) {
+		if c.Before == nil || c.After == nil || !SameContent(*c.Before, *c.After) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictError is returned when snippets changed after a journal entry was
+// recorded, so it cannot be undone or redone cleanly.
+type ConflictError struct {
+	Conflicts []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%d snippet(s) changed since: %s", len(e.Conflicts), strings.Join(e.Conflicts, "; "))
+}
+
+// Revert undoes the entry on snippets, returning the result. If a snippet
+// was changed after the entry was recorded a *ConflictError is returned,
+// unless force is set, in which case the entry's state wins.
+func (e JournalEntry) Revert(snippets []Snippet, force bool) ([]Snippet, error) {
+	return e.apply(snippets, true, force)
+}
+
+// Reapply redoes an entry that was reverted, the opposite of Revert.
+func (e JournalEntry) Reapply(snippets []Snippet, force bool) ([]Snippet, error) {
+	return e.apply(snippets, false, force)
+}
+
+func (e JournalEntry) apply(snippets []Snippet, reverse, force bool) ([]Snippet, error) {
+	type step struct {
+		id       string
+		index    int
+		from, to *Snippet
+	}
+	var removals, updates, inserts []step
+	for _, c := range e.Changes {
+		st := step{id: c.ID, index: c.Index, from: c.Before, to: c.After}
+		if reverse {
+			st.from, st.to = c.After, c.Before
+		}
+		switch {
+		case st.from == nil:
+			inserts = append(inserts, st)
+		case st.to == nil:
+			removals = append(removals, st)
+		default:
+			updates = append(updates, st)
+		}
+	}
+	// Insert in position order so each snippet lands where it was
+	sort.SliceStable(inserts, func(i, j int) bool { return inserts[i].index < inserts[j].index })
+
+	result := append([]Snippet{}, snippets...)
+	indexOf := func(id string) int {
+		for i, s := range result {
+			if s.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var conflicts []string
+	for _, st := range removals {
+		i := indexOf(st.id)
+		if i < 0 {
+			continue
+		}
+		if !SameContent(result[i], *st.from) {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' (%s) was edited", result[i].Title, st.id))
+		}
+		result = append(result[:i], result[i+1:]...)
+	}
+	for _, st := range updates {
+		i := indexOf(st.id)
+		if i < 0 {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' (%s) was deleted", st.from.Title, st.id))
+			result = append(result, *st.to)
+			continue
+		}
+		current := result[i]
+		if !SameContent(current, *st.from) {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' (%s) was edited", current.Title, st.id))
+		}
+		// Keep any uses made after the change
+		restored := *st.to
+		restored.UsageCount += current.UsageCount - st.from.UsageCount
+		if current.LastUsed != st.from.LastUsed {
+			restored.LastUsed = current.LastUsed
+		}
+		result[i] = restored
+	}
+	for _, st := range inserts {
+		if i := indexOf(st.id); i >= 0 {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' (%s) already exists", result[i].Title, st.id))
+			result[i] = *st.to
+			continue
+		}
+		index := min(max(st.index, 0), len(result))
+		result = append(result[:index], append([]Snippet{*st.to}, result[index:]...)...)
+	}
+
+	if len(conflicts) > 0 && !force {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	return result, nil
+}
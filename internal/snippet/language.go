@@ -0,0 +1,114 @@
+package snippet
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// extensionLanguages maps file extensions to snippet languages.
+var extensionLanguages = map[string]string{
+	".sh":    "bash",
+	".bash":  "bash",
+	".zsh":   "zsh",
+	".fish":  "fish",
+	".ps1":   "powershell",
+	".bat":   "batch",
+	".cmd":   "batch",
+	".py":    "python",
+	".rb":    "ruby",
+	".pl":    "perl",
+	".php":   "php",
+	".js":    "javascript",
+	".mjs":   "javascript",
+	".cjs":   "javascript",
+	".ts":    "typescript",
+	".go":    "go",
+	".rs":    "rust",
+	".java":  "java",
+	".kt":    "kotlin",
+	".swift": "swift",
+	".c":     "c",
+	".h":     "c",
+	".cpp":   "cpp",
+	".cc":    "cpp",
+	".hpp":   "cpp",
+	".cs":    "csharp",
+	".lua":   "lua",
+	".r":     "r",
+	".sql":   "sql",
+	".html":  "html",
+	".css":   "css",
+	".json":  "json",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+	".toml":  "toml",
+	".xml":   "xml",
+	".md":    "markdown",
+	".tf":    "terraform",
+}
+
+// interpreterLanguages maps shebang interpreters to snippet languages.
+var interpreterLanguages = map[string]string{
+	"sh":      "sh",
+	"bash":    "bash",
+	"zsh":     "zsh",
+	"fish":    "fish",
+	"python":  "python",
+	"python3": "python",
+	"python2": "python",
+	"ruby":    "ruby",
+	"perl":    "perl",
+	"php":     "php",
+	"node":    "javascript",
+	"deno":    "typescript",
+	"pwsh":    "powershell",
+	"lua":     "lua",
+	"Rscript": "r",
+}
+
+// InferLanguage guesses a snippet's language from a file name, then from
+// the shebang line of code. It returns "" when neither gives an answer.
+func InferLanguage(filename, code string) string {
+	if filename != "" {
+		ext := strings.ToLower(filepath.Ext(filename))
+		if lang, ok := extensionLanguages[ext]; ok {
+			return lang
+		}
+		switch strings.ToLower(filepath.Base(filename)) {
+		case "dockerfile":
+			return "dockerfile"
+		case "makefile":
+			return "make"
+		}
+	}
+	return shebangLanguage(code)
+}
+
+func shebangLanguage(code string) string {
+	line, _, _ := strings.Cut(code, "\n")
+	if !strings.HasPrefix(line, "#!") {
+		return ""
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	// #!/usr/bin/env [-S] python3
+	if interpreter == "env" {
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") {
+				interpreter = f
+				break
+			}
+		}
+	}
+	if lang, ok := interpreterLanguages[interpreter]; ok {
+		return lang
+	}
+	// python3.12 and the like
+	if i := strings.IndexAny(interpreter, "0123456789"); i > 0 {
+		return interpreterLanguages[interpreter[:i]]
+	}
+	return ""
+}
@@ -5,16 +5,16 @@ import (
 )
 
 type Snippet struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Code        string   `json:"code"`
-	Tags        []string `json:"tags"`
-	Executable  bool     `json:"executable"`
-	Language    string   `json:"language"`
-	Description string   `json:"description"`
-	UsageCount  int      `json:"usage_count"`
-	LastUsed    string   `json:"last_used"`
-	CreatedAt   string   `json:"created_at"`
+	ID          string   `json:"id" yaml:"id"`
+	Title       string   `json:"title" yaml:"title"`
+	Code        string   `json:"code" yaml:"code"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	Executable  bool     `json:"executable" yaml:"executable"`
+	Language    string   `json:"language" yaml:"language"`
+	Description string   `json:"description" yaml:"description"`
+	UsageCount  int      `json:"usage_count" yaml:"usage_count"`
+	LastUsed    string   `json:"last_used" yaml:"last_used"`
+	CreatedAt   string   `json:"created_at" yaml:"created_at"`
 }
 
 func NewSnippet(title, code, desc, lang string, tags []string, executable bool) *Snippet {
@@ -23,7 +23,7 @@ func NewSnippet(title, code, desc, lang string, tags []string, executable bool)
 		ID:          generateID(),
 		Title:       title,
 		Code:        code,
-		Tags:        tags,
+		Tags:        NormalizeTags(tags),
 		Executable:  executable,
 		Language:    lang,
 		Description: desc,
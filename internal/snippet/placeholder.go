@@ -0,0 +1,85 @@
+package snippet
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholders mark the parts of a snippet that should be filled in before
+// use, written {{name}} or {{name:default}}. Importers convert the variable
+// syntax of other tools into this form. A name starts with a letter or
+// underscore, or is a number for tab stops imported from VS Code, so
+// template syntax such as Go's {{.Release.Name}} is left alone.
+var (
+	placeholderName    = `[A-Za-z_][A-Za-z0-9_./-]*|[0-9]+`
+	placeholderPattern = regexp.MustCompile(`\{\{(` + placeholderName + `)(?::([^{}]*))?\}\}`)
+	validName          = regexp.MustCompile(`^(?:` + placeholderName + `)$`)
+	nonNameChars       = regexp.MustCompile(`[^A-Za-z0-9_./-]+`)
+)
+
+// PlaceholderRef is one placeholder found in a snippet's code.
+type PlaceholderRef struct {
+	Name    string
+	Default string
+	// HasDefault distinguishes {{name:}} from {{name}}.
+	HasDefault bool
+}
+
+// Placeholder formats a placeholder for name with an optional default.
+func Placeholder(name, def string) string {
+	if def == "" {
+		return "{{" + name + "}}"
+	}
+	return "{{" + name + ":" + def + "}}"
+}
+
+// PlaceholderName turns free text, such as the label of another tool's
+// placeholder, into a valid placeholder name, or "" if nothing is left.
+func PlaceholderName(text string) string {
+	name := strings.Trim(nonNameChars.ReplaceAllString(text, "_"), "_./-")
+	if name == "" || validName.MatchString(name) {
+		return name
+	}
+	return "_" + name
+}
+
+// Placeholders returns the placeholders in code in order of first use,
+// each name once.
+func Placeholders(code string) []PlaceholderRef {
+	var refs []PlaceholderRef
+	seen := map[string]bool{}
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[m[2]:m[3]]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		ref := PlaceholderRef{Name: name}
+		if m[4] >= 0 {
+			ref.Default = code[m[4]:m[5]]
+			ref.HasDefault = true
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// ReplacePlaceholders rewrites every placeholder in code with the result of
+// fn, for exporters that translate placeholders into another tool's syntax.
+// Text outside placeholders is passed through text.
+func ReplacePlaceholders(code string, fn func(ref PlaceholderRef) string, text func(string) string) string {
+	var out strings.Builder
+	last := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(code, -1) {
+		out.WriteString(text(code[last:m[0]]))
+		ref := PlaceholderRef{Name: code[m[2]:m[3]]}
+		if m[4] >= 0 {
+			ref.Default = code[m[4]:m[5]]
+			ref.HasDefault = true
+		}
+		out.WriteString(fn(ref))
+		last = m[1]
+	}
+	out.WriteString(text(code[last:]))
+	return out.String()
+}
@@ -0,0 +1,113 @@
+package snippet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned by Resolve when no snippet matches a reference.
+var ErrNotFound = errors.New("snippet not found")
+
+// AmbiguousError is returned by Resolve when a reference matches more than
+// one snippet at the same resolution stage.
+type AmbiguousError struct {
+	Ref        string
+	Stage      string
+	Candidates []Snippet
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("'%s' is ambiguous: %d snippets match by %s", e.Ref, len(e.Candidates), e.Stage)
+}
+
+// Resolve finds the index of the snippet referenced by ref. References are
+// tried, in order, as an exact ID, a unique ID prefix (git-style) or short
+// alias (see ShortIDs), an exact title (case-insensitive) and finally a
+// single fuzzy title match. A prefix that matches several IDs is only
+// reported as ambiguous when no title matches ref exactly, so short titles
+// stay reachable.
+func Resolve(snippets []Snippet, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, ErrNotFound
+	}
+
+	// Exact ID
+	for i, s := range snippets {
+		if s.ID == ref {
+			return i, nil
+		}
+	}
+
+	stages := []struct {
+		name  string
+		exact bool
+		match func(Snippet) bool
+	}{
+		{"ID prefix", false, func(s Snippet) bool {
+			return MatchesID(s.ID, ref)
+		}},
+		{"title", true, func(s Snippet) bool {
+			return strings.EqualFold(s.Title, ref)
+		}},
+		{"fuzzy title", false, func(s Snippet) bool {
+			return fuzzyMatch(s.Title, ref)
+		}},
+	}
+
+	var ambiguous *AmbiguousError
+	for _, stage := range stages {
+		// Once a stage is ambiguous, only an exact title can still win
+		if ambiguous != nil && !stage.exact {
+			continue
+		}
+		var hits []int
+		for i, s := range snippets {
+			if stage.match(s) {
+				hits = append(hits, i)
+			}
+		}
+		switch len(hits) {
+		case 0:
+			continue
+		case 1:
+			return hits[0], nil
+		default:
+			candidates := make([]Snippet, len(hits))
+			for j, idx := range hits {
+				candidates[j] = snippets[idx]
+			}
+			if ambiguous == nil {
+				ambiguous = &AmbiguousError{Ref: ref, Stage: stage.name, Candidates: candidates}
+			}
+		}
+	}
+
+	if ambiguous != nil {
+		return -1, ambiguous
+	}
+	return -1, ErrNotFound
+}
+
+// fuzzyMatch reports whether every character of query appears in text in
+// order, ignoring case and whitespace.
+func fuzzyMatch(text, query string) bool {
+	text = strings.ToLower(text)
+	query = strings.ToLower(strings.Join(strings.Fields(query), ""))
+	if query == "" {
+		return false
+	}
+
+	q := []rune(query)
+	pos := 0
+	for _, r := range text {
+		if r == q[pos] {
+			pos++
+			if pos == len(q) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package snippet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSimilarityThreshold is the score at or above which two snippets are
+// considered near duplicates.
+const DefaultSimilarityThreshold = 0.85
+
+// NormalizeCode reduces code to a canonical form for duplicate detection:
+// surrounding whitespace is trimmed, runs of whitespace are collapsed and
+// blank lines are dropped.
+func NormalizeCode(code string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			lines = append(lines, strings.Join(fields, " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CodeHash returns a stable hash of the normalized code.
+func CodeHash(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Fingerprint holds what Similarity needs from one piece of code, so code
+// compared many times is only prepared once.
+type Fingerprint struct {
+	normalized string
+	trigrams   map[string]bool
+}
+
+// NewFingerprint prepares code for comparison.
+func NewFingerprint(code string) Fingerprint {
+	normalized := NormalizeCode(code)
+	return Fingerprint{normalized: normalized, trigrams: trigrams(normalized)}
+}
+
+// Similarity scores how alike two pieces of code are, from 0 (nothing in
+// common) to 1 (identical after normalization). It uses the Dice coefficient
+// over character trigrams of the normalized code.
+func (a Fingerprint) Similarity(b Fingerprint) float64 {
+	if a.normalized == b.normalized {
+		return 1
+	}
+	if len(a.trigrams) == 0 || len(b.trigrams) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range a.trigrams {
+		if b.trigrams[t] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a.trigrams)+len(b.trigrams))
+}
+
+func trigrams(s string) map[string]bool {
+	runes := []rune(s)
+	set := make(map[string]bool)
+	if len(runes) < 3 {
+		if len(runes) > 0 {
+			set[s] = true
+		}
+		return set
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
+
+// DuplicateGroup is a set of snippets whose code is identical or nearly so.
+// The first index is the oldest snippet, which the others are compared to;
+// Score is the lowest of those similarities.
+type DuplicateGroup struct {
+	Indices []int
+	Exact   bool
+	Score   float64
+}
+
+// FindDuplicates groups snippets whose code is at least threshold similar to
+// the oldest snippet in the group. Every member is compared with that one
+// rather than with each other, so similarity does not chain: if A is like B
+// and B is like C, C only joins A's group if it is like A too. Indices within
+// a group are ordered by creation time, oldest first.
+func FindDuplicates(snippets []Snippet, threshold float64) []DuplicateGroup {
+	prints := make([]Fingerprint, len(snippets))
+	for i, s := range snippets {
+		prints[i] = NewFingerprint(s.Code)
+	}
+	identical := func(i, j int) bool { return prints[i].normalized == prints[j].normalized }
+	return groupDuplicates(snippets, threshold, identical, func(i, j int) float64 {
+		return prints[i].Similarity(prints[j])
+	})
+}
+
+// FindExactDuplicates groups snippets whose normalized code is identical.
+func FindExactDuplicates(snippets []Snippet) []DuplicateGroup {
+	hashes := make([]string, len(snippets))
+	for i, s := range snippets {
+		hashes[i] = CodeHash(s.Code)
+	}
+	identical := func(i, j int) bool { return hashes[i] == hashes[j] }
+	return groupDuplicates(snippets, 1, identical, func(i, j int) float64 {
+		if identical(i, j) {
+			return 1
+		}
+		return 0
+	})
+}
+
+// groupDuplicates takes the oldest snippet not yet grouped and groups it
+// with every other ungrouped snippet that scores at least threshold against
+// it, until none are left. A group is exact when all of its code is
+// identical to the oldest snippet's.
+func groupDuplicates(snippets []Snippet, threshold float64, identical func(i, j int) bool, score func(i, j int) float64) []DuplicateGroup {
+	order := make([]int, len(snippets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return snippets[order[a]].CreatedAt < snippets[order[b]].CreatedAt
+	})
+
+	grouped := make([]bool, len(snippets))
+	var groups []DuplicateGroup
+	for n, oldest := range order {
+		if grouped[oldest] {
+			continue
+		}
+		g := DuplicateGroup{Indices: []int{oldest}, Exact: true, Score: 1}
+		for _, i := range order[n+1:] {
+			if grouped[i] {
+				continue
+			}
+			s := score(oldest, i)
+			if s < threshold {
+				continue
+			}
+			g.Indices = append(g.Indices, i)
+			g.Score = min(g.Score, s)
+			g.Exact = g.Exact && identical(oldest, i)
+		}
+		if len(g.Indices) < 2 {
+			continue
+		}
+		for _, i := range g.Indices {
+			grouped[i] = true
+		}
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(a, b int) bool {
+		return groups[a].Indices[0] < groups[b].Indices[0]
+	})
+	return groups
+}
+
+// Merge folds other into s: tags are unioned, usage counts summed, the
+// earliest CreatedAt and the latest LastUsed are kept.
+func (s *Snippet) Merge(other Snippet) {
+	for _, tag := range other.Tags {
+		s.AddTag(tag)
+	}
+
+	s.UsageCount += other.UsageCount
+
+	if otherCreated, ok := parseTime(other.CreatedAt); ok {
+		if created, ok := parseTime(s.CreatedAt); !ok || otherCreated.Before(created) {
+			s.CreatedAt = other.CreatedAt
+		}
+	}
+	if otherUsed, ok := parseTime(other.LastUsed); ok {
+		if used, ok := parseTime(s.LastUsed); !ok || otherUsed.After(used) {
+			s.LastUsed = other.LastUsed
+		}
+	}
+}
+
+func parseTime(value string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
@@ -0,0 +1,150 @@
+package snippet
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TagSeparator splits hierarchical tags such as "cloud/aws/s3" into levels.
+const TagSeparator = "/"
+
+// NormalizeTag returns the canonical form of a tag: lower case, trimmed, with
+// inner runs of whitespace replaced by a single hyphen, so "Docker" and
+// " docker " are the same tag. Each level of a hierarchical tag is
+// normalized on its own and empty levels are dropped, so "Cloud / AWS/"
+// becomes "cloud/aws".
+func NormalizeTag(tag string) string {
+	var levels []string
+	for _, level := range strings.Split(tag, TagSeparator) {
+		if level = strings.Join(strings.Fields(strings.ToLower(level)), "-"); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, TagSeparator)
+}
+
+// TagWithin reports whether tag is ancestor or one of its descendants, e.g.
+// "cloud/aws/s3" is within "cloud/aws" and "cloud" but not "cloud/a".
+func TagWithin(tag, ancestor string) bool {
+	tag, ancestor = NormalizeTag(tag), NormalizeTag(ancestor)
+	return ancestor != "" && (tag == ancestor || strings.HasPrefix(tag, ancestor+TagSeparator))
+}
+
+// TagAncestors returns tag and every level above it, outermost first:
+// "cloud/aws/s3" gives "cloud", "cloud/aws" and "cloud/aws/s3".
+func TagAncestors(tag string) []string {
+	levels := strings.Split(NormalizeTag(tag), TagSeparator)
+	var ancestors []string
+	for i := range levels {
+		if levels[i] != "" {
+			ancestors = append(ancestors, strings.Join(levels[:i+1], TagSeparator))
+		}
+	}
+	return ancestors
+}
+
+// NormalizeTags normalizes each tag and drops empty and duplicate ones,
+// keeping the first occurrence's position.
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		if t = NormalizeTag(t); t != "" && !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized
+}
+
+// HasTag reports whether the snippet has exactly tag, after normalization.
+// Use MatchesTag to include descendants of a hierarchical tag.
+func (s Snippet) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, t := range s.Tags {
+		if NormalizeTag(t) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTag reports whether the snippet has tag or one of its descendants,
+// so a snippet tagged "cloud/aws/s3" matches "cloud/aws".
+func (s Snippet) MatchesTag(tag string) bool {
+	for _, t := range s.Tags {
+		if TagWithin(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenameTag replaces tag old with tag new, keeping its position, and reports
+// whether the snippet had old. Descendants move with it, so renaming "cloud"
+// to "infra" turns "cloud/aws" into "infra/aws". Duplicates that result are
+// dropped.
+func (s *Snippet) RenameTag(old, new string) bool {
+	old, new = NormalizeTag(old), NormalizeTag(new)
+	if old == new || new == "" || !s.MatchesTag(old) {
+		return false
+	}
+	for i, t := range s.Tags {
+		if TagWithin(t, old) {
+			s.Tags[i] = new + strings.TrimPrefix(NormalizeTag(t), old)
+		}
+	}
+	s.Tags = NormalizeTags(s.Tags)
+	return true
+}
+
+// TagNode is one level of the tag hierarchy. Count is the number of
+// snippets tagged with the node or any of its descendants; Snippets are
+// those tagged with the node itself.
+type TagNode struct {
+	Name     string     `json:"name" yaml:"name"`
+	Path     string     `json:"path" yaml:"path"`
+	Count    int        `json:"count" yaml:"count"`
+	Snippets []string   `json:"snippets,omitempty" yaml:"snippets,omitempty"`
+	Children []*TagNode `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// TagTree arranges the tags of snippets into a hierarchy and returns the top
+// level nodes, sorted by name. Snippets lists snippet IDs.
+func TagTree(snippets []Snippet) []*TagNode {
+	root := &TagNode{}
+	nodes := map[string]*TagNode{"": root}
+	for _, s := range snippets {
+		counted := map[string]bool{}
+		for _, tag := range s.Tags {
+			parent := root
+			for _, path := range TagAncestors(tag) {
+				node, ok := nodes[path]
+				if !ok {
+					name := path[strings.LastIndex(path, TagSeparator)+1:]
+					node = &TagNode{Name: name, Path: path}
+					nodes[path] = node
+					parent.Children = append(parent.Children, node)
+				}
+				if !counted[path] {
+					counted[path] = true
+					node.Count++
+				}
+				parent = node
+			}
+			if !slices.Contains(parent.Snippets, s.ID) {
+				parent.Snippets = append(parent.Snippets, s.ID)
+			}
+		}
+	}
+	sortTagNodes(root.Children)
+	return root.Children
+}
+
+func sortTagNodes(nodes []*TagNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, n := range nodes {
+		sortTagNodes(n.Children)
+	}
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+var backupDir = filepath.Join(filepath.Dir(storagePath), "backups")
+
+const (
+	// BackupTimeFormat names snapshots; it sorts chronologically.
+	BackupTimeFormat = "20060102-150405"
+	backupPrefix     = "snippets-"
+	manualSuffix     = "-manual"
+
+	// Automatic snapshots are thinned out as they age: the newest few are
+	// always kept, then one per hour for a day and one per day for a month.
+	keepRecentBackups = 10
+	hourlyBackupsFor  = 24 * time.Hour
+	dailyBackupsFor   = 30 * 24 * time.Hour
+)
+
+// Backup is a snapshot of the snippet store. Name is its timestamp, with a
+// "-manual" suffix for manual backups.
+type Backup struct {
+	Name string    `json:"name" yaml:"name"`
+	Time time.Time `json:"time" yaml:"time"`
+	// Manual backups come from 'codestash backup create' and are never
+	// pruned.
+	Manual bool   `json:"manual" yaml:"manual"`
+	Size   int64  `json:"size" yaml:"size"`
+	Path   string `json:"path" yaml:"path"`
+}
+
+// ListBackups returns the snapshots in the backup directory, newest first.
+func ListBackups() ([]Backup, error) {
+	entries, err := os.ReadDir(backupDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Backup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []Backup{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		stamp, ok := strings.CutPrefix(name, backupPrefix)
+		if !ok {
+			continue
+		}
+		base, manual := strings.CutSuffix(stamp, manualSuffix)
+		t, err := time.Parse(BackupTimeFormat, base)
+		if err != nil {
+			continue
+		}
+		b := Backup{Name: stamp, Time: t, Manual: manual, Path: filepath.Join(backupDir, e.Name())}
+		if info, err := e.Info(); err == nil {
+			b.Size = info.Size()
+		}
+		backups = append(backups, b)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// FindBackup returns the backup named ref, or else the newest one whose name
+// starts with ref, so "20260314" picks the last backup of that day. "latest"
+// is the newest backup.
+func FindBackup(ref string) (*Backup, error) {
+	backups, err := ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	ref = strings.TrimSpace(ref)
+	for i, b := range backups {
+		if b.Name == ref {
+			return &backups[i], nil
+		}
+	}
+	for i, b := range backups {
+		if ref == "latest" || (ref != "" && strings.HasPrefix(b.Name, ref)) {
+			return &backups[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no backup matches '%s'", ref)
+}
+
+// LoadBackup reads the snippets saved in b.
+func LoadBackup(b Backup) ([]snippet.Snippet, error) {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return nil, err
+	}
+	var snippets []snippet.Snippet
+	if err := json.Unmarshal(data, &snippets); err != nil {
+		return nil, fmt.Errorf("backup %s is not valid: %v", b.Name, err)
+	}
+	return snippets, nil
+}
+
+// CreateBackup snapshots the current snippet store. Manual backups are kept
+// until deleted by hand. It returns nil when there is nothing to back up, or
+// for an automatic snapshot, when the store is unchanged since the newest
+// snapshot or is not valid JSON.
+func CreateBackup(manual bool) (*Backup, error) {
+	data, err := os.ReadFile(storagePath)
+	if errors.Is(err, os.ErrNotExist) || len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups, err := ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	// A corrupted store is not worth rotating good snapshots out for
+	if !manual && !json.Valid(data) {
+		return nil, nil
+	}
+	if len(backups) > 0 && !manual {
+		if latest, err := os.ReadFile(backups[0].Path); err == nil && bytes.Equal(latest, data) {
+			return nil, nil
+		}
+	}
+
+	now := time.Now().UTC()
+	b := Backup{Name: now.Format(BackupTimeFormat), Time: now, Manual: manual, Size: int64(len(data))}
+	if manual {
+		b.Name += manualSuffix
+	}
+	b.Path = filepath.Join(backupDir, backupPrefix+b.Name+".json")
+
+	// Several saves within a second keep the oldest state
+	if _, err := os.Stat(b.Path); err == nil {
+		return &b, nil
+	}
+	if err := os.MkdirAll(backupDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(b.Path, data); err != nil {
+		return nil, err
+	}
+	return &b, pruneBackups(now)
+}
+
+// pruneBackups removes the automatic snapshots that the retention policy no
+// longer needs.
+func pruneBackups(now time.Time) error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	keep := retainedBackups(backups, now)
+	for _, b := range backups {
+		if !keep[b.Path] {
+			if err := os.Remove(b.Path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// retainedBackups applies the retention policy to backups, which are sorted
+// newest first, and returns the paths to keep.
+func retainedBackups(backups []Backup, now time.Time) map[string]bool {
+	keep := make(map[string]bool)
+	buckets := make(map[string]bool)
+	recent := 0
+	for _, b := range backups {
+		age := now.Sub(b.Time)
+		var bucket string
+		switch {
+		case b.Manual:
+			keep[b.Path] = true
+			continue
+		case recent < keepRecentBackups:
+			recent++
+			keep[b.Path] = true
+			continue
+		case age <= hourlyBackupsFor:
+			bucket = b.Time.Format("2006010215")
+		case age <= dailyBackupsFor:
+			bucket = b.Time.Format("20060102")
+		default:
+			continue
+		}
+		// The newest snapshot in each bucket wins
+		if !buckets[bucket] {
+			buckets[bucket] = true
+			keep[b.Path] = true
+		}
+	}
+	return keep
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so an interrupted write never leaves a truncated file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+var collectionsPath = filepath.Join(filepath.Dir(storagePath), "collections.json")
+
+func LoadCollections() ([]snippet.Collection, error) {
+	file, err := os.ReadFile(collectionsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []snippet.Collection{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var collections []snippet.Collection
+	if err := json.Unmarshal(file, &collections); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func SaveCollections(collections []snippet.Collection) error {
+	os.MkdirAll(filepath.Dir(collectionsPath), os.ModePerm)
+	data, err := json.MarshalIndent(collections, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(collectionsPath, data, 0644)
+}
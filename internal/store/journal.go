@@ -0,0 +1,84 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+var journalPath = filepath.Join(filepath.Dir(storagePath), "journal.jsonl")
+
+// defaultJournalSize is how many changes the journal keeps unless
+// CODESTASH_JOURNAL_SIZE says otherwise; the oldest entries are dropped
+// first.
+const defaultJournalSize = 200
+
+func journalSize() int {
+	if n, err := strconv.Atoi(os.Getenv("CODESTASH_JOURNAL_SIZE")); err == nil && n > 0 {
+		return n
+	}
+	return defaultJournalSize
+}
+
+// LoadJournal reads the change journal, oldest entry first. Malformed lines
+// are skipped.
+func LoadJournal() ([]snippet.JournalEntry, error) {
+	file, err := os.Open(journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []snippet.JournalEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []snippet.JournalEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var e snippet.JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// RecordChange appends an entry to the change journal. Undone entries can no
+// longer be redone once a new change is made, so they are dropped, as are
+// the oldest entries beyond the journal size.
+func RecordChange(e snippet.JournalEntry) error {
+	entries, err := LoadJournal()
+	if err != nil {
+		return err
+	}
+	for len(entries) > 0 && entries[len(entries)-1].Undone {
+		entries = entries[:len(entries)-1]
+	}
+	entries = append(entries, e)
+	if size := journalSize(); len(entries) > size {
+		entries = entries[len(entries)-size:]
+	}
+	return SaveJournal(entries)
+}
+
+// SaveJournal replaces the change journal with entries.
+func SaveJournal(entries []snippet.JournalEntry) error {
+	os.MkdirAll(filepath.Dir(journalPath), os.ModePerm)
+	var data []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+	return os.WriteFile(journalPath, data, 0644)
+}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+// StoragePath returns the path of the snippet store.
+func StoragePath() string {
+	return storagePath
+}
+
+// RecoverSnippets salvages what it can from a snippet store that is not
+// valid JSON, such as one cut short by an interrupted write. Every complete
+// snippet object in the file is kept; lost counts the objects that could not
+// be read, though a damaged object can hide others after it.
+func RecoverSnippets() (recovered []snippet.Snippet, lost int, err error) {
+	data, err := os.ReadFile(storagePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	recovered = []snippet.Snippet{}
+	objects, lost := scanObjects(data)
+	for _, object := range objects {
+		var s snippet.Snippet
+		if err := json.Unmarshal(object, &s); err != nil || (s.ID == "" && s.Code == "") {
+			lost++
+			continue
+		}
+		s.Tags = snippet.NormalizeTags(s.Tags)
+		recovered = append(recovered, s)
+	}
+	return recovered, lost, nil
+}
+
+// scanObjects returns the snippet objects in data that are valid JSON, and
+// counts the ones that are not. Objects are found where an array element can
+// start, so braces inside code are not mistaken for snippets; after a damaged
+// object the scan resumes at the next such place.
+func scanObjects(data []byte) (objects [][]byte, lost int) {
+	damagedUntil := -1
+	for i := nextObject(data, 0); i >= 0; i = nextObject(data, i+1) {
+		end := objectEnd(data, i)
+		// An object that is never closed was usually cut short by an
+		// interrupted write, but a damaged quote has the same effect, so
+		// keep looking for the snippets after it. Candidates inside a damaged
+		// object are most likely code, so they are not counted as lost.
+		if end < 0 || !json.Valid(data[i:end]) {
+			if i >= damagedUntil {
+				lost++
+				damagedUntil = len(data)
+				if end >= 0 {
+					damagedUntil = end
+				}
+			}
+			continue
+		}
+		objects = append(objects, data[i:end])
+		i = end - 1
+	}
+	return objects, lost
+}
+
+// nextObject returns the index of the next '{' at or after from that follows
+// '[', ',' or '}' (ignoring whitespace), or -1.
+func nextObject(data []byte, from int) int {
+	for i := from; i < len(data); i++ {
+		if data[i] != '{' {
+			continue
+		}
+		j := i - 1
+		for j >= 0 && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+			j--
+		}
+		if j < 0 || data[j] == '[' || data[j] == ',' || data[j] == '}' {
+			return i
+		}
+	}
+	return -1
+}
+
+// objectEnd returns the index just past the brace closing the object that
+// opens at start, or -1 if it is never closed.
+func objectEnd(data []byte, start int) int {
+	depth, inString, escaped := 0, false, false
+	for i := start; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -12,6 +13,19 @@ import (
 var storagePath = filepath.Join(os.Getenv("HOME"), ".codestash", "snippets.json")
 
 func LoadSnippets() ([]snippet.Snippet, error) {
+	snippets, err := readSnippets()
+	if err != nil {
+		return nil, err
+	}
+	// Tags saved before normalization existed may differ only in case
+	for i := range snippets {
+		snippets[i].Tags = snippet.NormalizeTags(snippets[i].Tags)
+	}
+	return snippets, nil
+}
+
+// readSnippets reads the stored snippets as they are on disk.
+func readSnippets() ([]snippet.Snippet, error) {
 	file, err := os.ReadFile(storagePath)
 	if errors.Is(err, os.ErrNotExist) {
 		return []snippet.Snippet{}, nil
@@ -26,11 +40,23 @@ func LoadSnippets() ([]snippet.Snippet, error) {
 	return snippets, nil
 }
 
+// SaveSnippets replaces the stored snippets. The previous file is
+// snapshotted into the backup directory first, unless only usage counts and
+// last-used times changed, and the new one is written atomically.
 func SaveSnippets(snippets []snippet.Snippet) error {
 	os.MkdirAll(filepath.Dir(storagePath), os.ModePerm)
 	data, err := json.MarshalIndent(snippets, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(storagePath, data, 0644)
+	// Every use saves the store; snapshotting those would soon rotate the
+	// state before a real change out of the retained backups. The file is
+	// compared as stored, so the save that first writes normalized tags is
+	// snapshotted too.
+	if current, err := readSnippets(); err != nil || !snippet.UsageOnly(current, snippets) {
+		if _, err := CreateBackup(false); err != nil {
+			return fmt.Errorf("backing up the current snippets: %v", err)
+		}
+	}
+	return writeFileAtomic(storagePath, data)
 }
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/AngeloMihaelle/CodeStash/internal/snippet"
+)
+
+var usagePath = filepath.Join(filepath.Dir(storagePath), "usage.jsonl")
+
+// maxUsageEvents caps the usage log; older events are dropped on compaction.
+const maxUsageEvents = 10000
+
+// LoadUsageEvents reads the usage event log. Malformed lines are skipped.
+func LoadUsageEvents() ([]snippet.UsageEvent, error) {
+	file, err := os.Open(usagePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []snippet.UsageEvent{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []snippet.UsageEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e snippet.UsageEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// RecordUsage appends an event to the usage log, compacting the log once it
+// grows past maxUsageEvents.
+func RecordUsage(e snippet.UsageEvent) error {
+	os.MkdirAll(filepath.Dir(usagePath), os.ModePerm)
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(usagePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(usagePath); err == nil && info.Size() > maxUsageEvents*100 {
+		return compactUsage()
+	}
+	return nil
+}
+
+// SaveUsageEvents replaces the usage log with events.
+func SaveUsageEvents(events []snippet.UsageEvent) error {
+	os.MkdirAll(filepath.Dir(usagePath), os.ModePerm)
+	var data []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+	return os.WriteFile(usagePath, data, 0644)
+}
+
+// MoveUsage reassigns the usage events of each snippet ID in moves to the ID
+// it maps to, such as when duplicates are merged into one snippet.
+func MoveUsage(moves map[string]string) error {
+	events, err := LoadUsageEvents()
+	if err != nil {
+		return err
+	}
+	moved := false
+	for i, e := range events {
+		if to, ok := moves[e.SnippetID]; ok {
+			events[i].SnippetID = to
+			moved = true
+		}
+	}
+	if !moved {
+		return nil
+	}
+	return SaveUsageEvents(events)
+}
+
+func compactUsage() error {
+	events, err := LoadUsageEvents()
+	if err != nil {
+		return err
+	}
+	if len(events) <= maxUsageEvents {
+		return nil
+	}
+	return SaveUsageEvents(events[len(events)-maxUsageEvents:])
+}
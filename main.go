@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,7 +10,9 @@ import (
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		fmt.Println("❌", err)
+		if !errors.Is(err, cmd.ErrReported) {
+			fmt.Fprintln(os.Stderr, "❌", err)
+		}
 		os.Exit(1)
 	}
 }